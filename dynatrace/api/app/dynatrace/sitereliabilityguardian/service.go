@@ -18,14 +18,10 @@
 package sitereliabilityguardian
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
-	"os"
 	"strings"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
@@ -33,10 +29,9 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20"
-	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/auth"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/client/auth"
 	crest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/clientcredentials"
 )
 
 const SchemaVersion = "1.3.1"
@@ -54,69 +49,15 @@ type service struct {
 	credentials *settings.Credentials
 }
 
-var httpListener = &crest.HTTPListener{
-	Callback: func(response crest.RequestResponse) {
-		if response.Request != nil {
-			if response.Request.URL != nil {
-				if response.Request.Body != nil {
-					body, _ := io.ReadAll(response.Request.Body)
-					rest.Logger.Println(response.Request.Method, response.Request.URL.String()+"\n    "+string(body))
-				} else {
-					rest.Logger.Println(response.Request.Method, response.Request.URL)
-				}
-			}
-		}
-		if response.Response != nil {
-			if response.Response.Body != nil {
-				if os.Getenv("DYNATRACE_HTTP_RESPONSE") == "true" {
-					body, _ := io.ReadAll(response.Response.Body)
-					if body != nil {
-						rest.Logger.Println(response.Response.StatusCode, string(body))
-					} else {
-						rest.Logger.Println(response.Response.StatusCode)
-					}
-				}
-			}
-		}
-	},
-}
-
-type LoggingRoundTripper struct {
-	RoundTripper http.RoundTripper
-}
-
-func (lrt *LoggingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	rest.Logger.Println(r.Method, r.URL)
-	if r.Body != nil {
-		buf := new(bytes.Buffer)
-		io.Copy(buf, r.Body)
-		rest.Logger.Println("  ", buf.String())
-		r.Body = io.NopCloser(bytes.NewBuffer(buf.Bytes()))
-	}
-	res, err := lrt.RoundTripper.RoundTrip(r)
-	if err != nil {
-		rest.Logger.Println("  error:", err.Error())
-	}
-	if res != nil && res.Body != nil {
-		buf := new(bytes.Buffer)
-		io.Copy(buf, res.Body)
-		rest.Logger.Println("  =>", buf.String())
-		res.Body = io.NopCloser(bytes.NewBuffer(buf.Bytes()))
-	}
-	return res, err
-}
-
 func (me *service) TokenClient() *crest.Client {
 	var parsedURL *url.URL
 	parsedURL, _ = url.Parse(me.credentials.URL)
 
 	tokenClient := crest.NewClient(
 		parsedURL,
-		http.DefaultClient,
-		crest.WithHTTPListener(httpListener),
+		me.credentials.GetHTTPClient(),
 	)
 
-	tokenClient.SetHeader("User-Agent", "Dynatrace Terraform Provider")
 	tokenClient.SetHeader("Authorization", "Api-Token "+me.credentials.Token)
 	return tokenClient
 }
@@ -127,23 +68,18 @@ func (me *service) Client(schemaIDs string) *settings20.Client {
 
 	tokenClient := me.TokenClient()
 
-	if os.Getenv("DYNATRACE_DEBUG_GENERIC_SETTINGS") == "true" {
-		http.DefaultClient.Transport = &LoggingRoundTripper{http.DefaultTransport}
-	}
-
 	oauthClient := crest.NewClient(
 		parsedURL,
-		auth.NewOAuthBasedClient(
-			context.TODO(),
-			clientcredentials.Config{
+		auth.NewPlatformClient(
+			me.credentials.HTTPContext(context.TODO()),
+			me.credentials.Automation.PlatformToken,
+			auth.OauthCredentials{
 				ClientID:     me.credentials.Automation.ClientID,
 				ClientSecret: me.credentials.Automation.ClientSecret,
 				TokenURL:     me.credentials.Automation.TokenURL,
 				AuthStyle:    oauth2.AuthStyleInParams}),
-		crest.WithHTTPListener(httpListener),
 	)
 
-	oauthClient.SetHeader("User-Agent", "Dynatrace Terraform Provider")
 	oauthClient.SetHeader("Authorization", "Api-Token "+me.credentials.Token)
 
 	return settings20.NewClient(tokenClient, oauthClient, schemaIDs)
@@ -20,7 +20,6 @@ package business_calendars
 import (
 	"context"
 	"encoding/json"
-	"net/http"
 	"net/url"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
@@ -29,7 +28,6 @@ import (
 
 	automationerr "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation"
 	business_calendars "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/business_calendars/settings"
-	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/httplog"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/client/auth"
 	apiClient "github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/automation"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
@@ -45,21 +43,13 @@ type service struct {
 }
 
 func (me *service) client() *automation.Client {
-	if _, ok := http.DefaultClient.Transport.(*httplog.RoundTripper); !ok {
-		if http.DefaultClient.Transport == nil {
-			http.DefaultClient.Transport = &httplog.RoundTripper{RoundTripper: http.DefaultTransport}
-		} else {
-			http.DefaultClient.Transport = &httplog.RoundTripper{RoundTripper: http.DefaultClient.Transport}
-		}
-	}
-	httpClient := auth.NewOAuthClient(context.TODO(), auth.OauthCredentials{
+	httpClient := auth.NewPlatformClient(me.credentials.HTTPContext(context.TODO()), me.credentials.Automation.PlatformToken, auth.OauthCredentials{
 		ClientID:     me.credentials.Automation.ClientID,
 		ClientSecret: me.credentials.Automation.ClientSecret,
 		TokenURL:     me.credentials.Automation.TokenURL,
 	})
 	u, _ := url.Parse(me.credentials.Automation.EnvironmentURL)
 	restClient := rest.NewClient(u, httpClient)
-	restClient.SetHeader("User-Agent", "Dynatrace Terraform Provider")
 	return automation.NewClient(restClient)
 }
 
@@ -20,7 +20,6 @@ package scheduling_rules
 import (
 	"context"
 	"encoding/json"
-	"net/http"
 	"net/url"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
@@ -28,7 +27,6 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 
 	automationerr "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation"
-	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/httplog"
 	scheduling_rules "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/automation/scheduling_rules/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/client/auth"
 	apiClient "github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/automation"
@@ -45,21 +43,13 @@ type service struct {
 }
 
 func (me *service) client() *automation.Client {
-	if _, ok := http.DefaultClient.Transport.(*httplog.RoundTripper); !ok {
-		if http.DefaultClient.Transport == nil {
-			http.DefaultClient.Transport = &httplog.RoundTripper{RoundTripper: http.DefaultTransport}
-		} else {
-			http.DefaultClient.Transport = &httplog.RoundTripper{RoundTripper: http.DefaultClient.Transport}
-		}
-	}
-	httpClient := auth.NewOAuthClient(context.TODO(), auth.OauthCredentials{
+	httpClient := auth.NewPlatformClient(me.credentials.HTTPContext(context.TODO()), me.credentials.Automation.PlatformToken, auth.OauthCredentials{
 		ClientID:     me.credentials.Automation.ClientID,
 		ClientSecret: me.credentials.Automation.ClientSecret,
 		TokenURL:     me.credentials.Automation.TokenURL,
 	})
 	u, _ := url.Parse(me.credentials.Automation.EnvironmentURL)
 	restClient := rest.NewClient(u, httpClient)
-	restClient.SetHeader("User-Agent", "Dynatrace Terraform Provider")
 	return automation.NewClient(restClient)
 }
 
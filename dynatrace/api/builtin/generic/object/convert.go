@@ -0,0 +1,215 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	object "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+// Encode produces the typed settings object expected by the Settings API out of the entries of
+// `value` and `secrets`. Every entry is getting converted according to the type of the property
+// it refers to. The returned violations refer to the entries which couldn't get converted.
+func Encode(sch *validation.Schema, v *object.Settings) []*validation.Violation {
+	violations := []*validation.Violation{}
+	v.Object = map[string]any{}
+	for attr, entries := range map[string]map[string]string{"value": v.Value, "secrets": v.Secrets} {
+		for key, entry := range entries {
+			path := validation.Path{attr, key}
+			property, found := sch.Properties[key]
+			if !found {
+				violations = append(violations, &validation.Violation{Path: path, Message: fmt.Sprintf("the schema `%s` doesn't contain a property `%s`", sch.SchemaID, key)})
+				continue
+			}
+			if secret := property.Type.Name == "secret"; secret != (attr == "secrets") {
+				if secret {
+					violations = append(violations, &validation.Violation{Path: path, Message: "properties of type `secret` need to be specified within `secrets`"})
+				} else {
+					violations = append(violations, &validation.Violation{Path: path, Message: "only properties of type `secret` can be specified within `secrets`"})
+				}
+				continue
+			}
+			if _, found := v.Object[key]; found {
+				violations = append(violations, &validation.Violation{Path: path, Message: "the property has been specified more than once"})
+				continue
+			}
+			value, err := decode(sch, property, entry)
+			if err != nil {
+				violations = append(violations, &validation.Violation{Path: path, Message: err.Error()})
+				continue
+			}
+			v.Object[key] = value
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Path.String() < violations[j].Path.String() })
+	return violations
+}
+
+// decode converts the string representation of a property into its typed value
+func decode(sch *validation.Schema, property *validation.Property, entry string) (any, error) {
+	switch property.Type.Name {
+	case "boolean":
+		value, err := strconv.ParseBool(entry)
+		if err != nil {
+			return nil, fmt.Errorf("`%s` is not a valid boolean", entry)
+		}
+		return value, nil
+	case "integer":
+		value, err := strconv.ParseInt(entry, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("`%s` is not a valid integer", entry)
+		}
+		return float64(value), nil
+	case "float":
+		value, err := strconv.ParseFloat(entry, 64)
+		if err != nil {
+			return nil, fmt.Errorf("`%s` is not a valid number", entry)
+		}
+		return value, nil
+	case "set", "list":
+		var value []any
+		if err := json.Unmarshal([]byte(entry), &value); err != nil {
+			return nil, fmt.Errorf("a %s needs to be specified as JSON encoded array, e.g. via `jsonencode()`", property.Type.Name)
+		}
+		return value, nil
+	}
+	if _, ok := property.Type.Type(); ok {
+		var value map[string]any
+		if err := json.Unmarshal([]byte(entry), &value); err != nil {
+			return nil, fmt.Errorf("an object needs to be specified as JSON encoded object, e.g. via `jsonencode()`")
+		}
+		return value, nil
+	}
+	if enumName, ok := property.Type.Enum(); ok {
+		// enum values are usually strings, the validation takes care of unknown values
+		if enum, found := sch.Enums[enumName]; found {
+			for _, item := range enum.Items {
+				if fmt.Sprint(item.Value) == entry {
+					return item.Value, nil
+				}
+			}
+		}
+	}
+	return entry, nil
+}
+
+// Decode fills `value` and `secrets` based on the typed settings object received from the Settings API.
+// The previous state is used to determine which properties are of interest. Properties not configured
+// are getting ignored unless there is no previous state (i.e. during import). Secrets can't get read back
+// from the API, hence the values of the previous state are kept for them.
+func Decode(sch *validation.Schema, v *object.Settings, state *object.Settings) {
+	v.Value = map[string]string{}
+	v.Secrets = map[string]string{}
+	imported := state == nil || (len(state.Value) == 0 && len(state.Secrets) == 0)
+	for key, value := range v.Object {
+		if value == nil {
+			continue
+		}
+		secret := false
+		if property, found := sch.Properties[key]; found {
+			secret = property.Type.Name == "secret"
+		}
+		if secret {
+			if !imported {
+				if stateValue, found := state.Secrets[key]; found {
+					v.Secrets[key] = stateValue
+				}
+			}
+			continue
+		}
+		if !imported {
+			stateValue, found := state.Value[key]
+			if !found {
+				continue
+			}
+			value = project(value, stateValue)
+		}
+		v.Value[key] = encode(value)
+	}
+}
+
+// encode produces the string representation of a property
+func encode(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+var secretReg = regexp.MustCompile(`^\*\*\*\d\d\d\*\*\*$`)
+
+// project reduces objects and lists received from the API to the shape of the previous state.
+// Properties the API has filled in with default values would otherwise show up as differences.
+// Masked secrets nested within objects and lists are getting restored from the previous state.
+func project(value any, stateEntry string) any {
+	if !strings.HasPrefix(stateEntry, "{") && !strings.HasPrefix(stateEntry, "[") {
+		return value
+	}
+	var stateValue any
+	if err := json.Unmarshal([]byte(stateEntry), &stateValue); err != nil {
+		return value
+	}
+	return projectValue(value, stateValue)
+}
+
+func projectValue(value any, stateValue any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		sv, ok := stateValue.(map[string]any)
+		if !ok {
+			return value
+		}
+		for key, elem := range v {
+			if stateElem, found := sv[key]; found {
+				v[key] = projectValue(elem, stateElem)
+			} else {
+				delete(v, key)
+			}
+		}
+		return v
+	case []any:
+		sv, ok := stateValue.([]any)
+		if !ok {
+			return value
+		}
+		for idx, elem := range v {
+			if idx < len(sv) {
+				v[idx] = projectValue(elem, sv[idx])
+			}
+		}
+		return v
+	case string:
+		if secretReg.MatchString(v) && stateValue != nil {
+			return stateValue
+		}
+	}
+	return value
+}
@@ -0,0 +1,155 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package object_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object"
+	objsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+const testSchema = `{
+	"schemaId": "app:test:connection",
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"port": { "type": "integer" },
+		"ratio": { "type": "float" },
+		"name": { "type": "text" },
+		"token": { "type": "secret" },
+		"mode": { "type": { "$ref": "#/enums/Mode" } },
+		"endpoint": { "type": { "$ref": "#/types/Endpoint" } },
+		"tags": { "type": "set", "items": { "type": "text" } }
+	},
+	"types": {
+		"Endpoint": {
+			"properties": {
+				"url": { "type": "text" },
+				"password": { "type": "secret" },
+				"timeout": { "type": "integer" }
+			}
+		}
+	},
+	"enums": {
+		"Mode": { "items": [ { "value": "PUSH" }, { "value": "PULL" } ] }
+	}
+}`
+
+func loadTestSchema(t *testing.T) *validation.Schema {
+	t.Helper()
+	sch, err := validation.Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sch
+}
+
+func TestEncode(t *testing.T) {
+	sch := loadTestSchema(t)
+	v := &objsettings.Settings{
+		Value: map[string]string{
+			"enabled":  "true",
+			"port":     "8080",
+			"ratio":    "0.5",
+			"name":     "my connection",
+			"mode":     "PULL",
+			"endpoint": `{"url":"https://example.com","timeout":10}`,
+			"tags":     `["a","b"]`,
+		},
+		Secrets: map[string]string{"token": "s3cr3t"},
+	}
+	if violations := object.Encode(sch, v); len(violations) > 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+	expected := map[string]any{
+		"enabled":  true,
+		"port":     float64(8080),
+		"ratio":    0.5,
+		"name":     "my connection",
+		"mode":     "PULL",
+		"endpoint": map[string]any{"url": "https://example.com", "timeout": float64(10)},
+		"tags":     []any{"a", "b"},
+		"token":    "s3cr3t",
+	}
+	if !reflect.DeepEqual(v.Object, expected) {
+		t.Errorf("expected %v, got %v", expected, v.Object)
+	}
+}
+
+func TestEncodeViolations(t *testing.T) {
+	sch := loadTestSchema(t)
+	v := &objsettings.Settings{
+		Value: map[string]string{
+			"enabled":  "yes",
+			"unknown":  "1",
+			"token":    "s3cr3t",
+			"endpoint": "https://example.com",
+		},
+		Secrets: map[string]string{"name": "my connection"},
+	}
+	violations := object.Encode(sch, v)
+	paths := []string{}
+	for _, violation := range violations {
+		paths = append(paths, violation.Path.String())
+	}
+	expected := []string{"secrets.name", "value.enabled", "value.endpoint", "value.token", "value.unknown"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected violations for %v, got %v", expected, violations)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	sch := loadTestSchema(t)
+	state := &objsettings.Settings{
+		Value: map[string]string{
+			"enabled":  "true",
+			"endpoint": `{"url":"https://example.com","password":"pwd"}`,
+		},
+		Secrets: map[string]string{"token": "s3cr3t"},
+	}
+	v := &objsettings.Settings{Object: map[string]any{
+		"enabled":  false,
+		"port":     float64(8080),
+		"token":    "***123***",
+		"endpoint": map[string]any{"url": "https://example.com", "password": "***123***", "timeout": float64(30)},
+	}}
+	object.Decode(sch, v, state)
+	expectedValue := map[string]string{
+		"enabled":  "false",
+		"endpoint": `{"password":"pwd","url":"https://example.com"}`,
+	}
+	if !reflect.DeepEqual(v.Value, expectedValue) {
+		t.Errorf("expected %v, got %v", expectedValue, v.Value)
+	}
+	if !reflect.DeepEqual(v.Secrets, state.Secrets) {
+		t.Errorf("expected secrets %v, got %v", state.Secrets, v.Secrets)
+	}
+
+	// without previous state (e.g. during import) all properties are of interest
+	v = &objsettings.Settings{Object: map[string]any{"enabled": false, "port": float64(8080), "token": "***123***"}}
+	object.Decode(sch, v, nil)
+	expectedValue = map[string]string{"enabled": "false", "port": "8080"}
+	if !reflect.DeepEqual(v.Value, expectedValue) {
+		t.Errorf("expected %v, got %v", expectedValue, v.Value)
+	}
+	if len(v.Secrets) > 0 {
+		t.Errorf("expected no secrets, got %v", v.Secrets)
+	}
+}
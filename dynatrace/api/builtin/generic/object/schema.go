@@ -0,0 +1,84 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+var liveSchemas = map[string]*validation.Schema{}
+var liveSchemasLock sync.Mutex
+
+// LoadSchema returns the schema with the given ID. The schema bundled with the provider is preferred.
+// In case the schema isn't bundled or it doesn't know about all of the given properties (i.e. the environment
+// runs a newer version of the schema) it is getting fetched from the environment.
+func LoadSchema(credentials *settings.Credentials, schemaID string, properties ...string) (*validation.Schema, error) {
+	bundled, err := validation.Load(schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if bundled != nil && containsAll(bundled, properties) {
+		return bundled, nil
+	}
+	live, err := fetchSchema(credentials, schemaID)
+	if err != nil {
+		if bundled != nil {
+			return bundled, nil
+		}
+		return nil, err
+	}
+	return live, nil
+}
+
+func containsAll(sch *validation.Schema, properties []string) bool {
+	for _, property := range properties {
+		if _, found := sch.Properties[property]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func fetchSchema(credentials *settings.Credentials, schemaID string) (*validation.Schema, error) {
+	liveSchemasLock.Lock()
+	defer liveSchemasLock.Unlock()
+	if sch, found := liveSchemas[schemaID]; found {
+		return sch, nil
+	}
+	if credentials == nil || len(credentials.URL) == 0 || len(credentials.Token) == 0 {
+		return nil, fmt.Errorf("the schema `%s` is not known to this version of the provider and no environment is configured to fetch it from", schemaID)
+	}
+	var data json.RawMessage
+	client := rest.DefaultClient(credentials.URL, credentials.Token)
+	if err := client.Get(fmt.Sprintf("/api/v2/settings/schemas/%s", url.PathEscape(schemaID)), 200).Finish(&data); err != nil {
+		return nil, fmt.Errorf("unable to fetch the schema `%s`: %s", schemaID, err.Error())
+	}
+	sch, err := validation.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the schema `%s`: %s", schemaID, err.Error())
+	}
+	liveSchemas[schemaID] = sch
+	return sch, nil
+}
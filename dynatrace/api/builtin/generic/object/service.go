@@ -0,0 +1,82 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	object "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20"
+)
+
+// Service manages settings objects of arbitrary schemas.
+// The schema is determined by the settings object passed, hence listing isn't supported.
+func Service(credentials *settings.Credentials) settings.CRUDService[*object.Settings] {
+	return &service{credentials: credentials}
+}
+
+type service struct {
+	credentials *settings.Credentials
+}
+
+func (me *service) service(schemaID string) settings.CRUDService[*object.Settings] {
+	return settings20.Service[*object.Settings](me.credentials, schemaID, "")
+}
+
+// Get fetches the settings object with the given ID. In case `v` doesn't specify
+// a schema yet (i.e. during import) it is getting looked up first.
+func (me *service) Get(ctx context.Context, id string, v *object.Settings) error {
+	if len(v.SchemaID) == 0 {
+		var settingsObject settings20.SettingsObject
+		client := rest.DefaultClient(me.credentials.URL, me.credentials.Token)
+		if err := client.Get(fmt.Sprintf("/api/v2/settings/objects/%s", url.PathEscape(id)), 200).Finish(&settingsObject); err != nil {
+			return err
+		}
+		v.SchemaID = settingsObject.SchemaID
+	}
+	return me.service(v.SchemaID).Get(ctx, id, v)
+}
+
+func (me *service) List(ctx context.Context) (api.Stubs, error) {
+	return api.Stubs{}, nil
+}
+
+func (me *service) Validate(v *object.Settings) error {
+	return nil
+}
+
+func (me *service) Create(ctx context.Context, v *object.Settings) (*api.Stub, error) {
+	return me.service(v.SchemaID).Create(ctx, v)
+}
+
+func (me *service) Update(ctx context.Context, id string, v *object.Settings) error {
+	return me.service(v.SchemaID).Update(ctx, id, v)
+}
+
+func (me *service) Delete(ctx context.Context, id string) error {
+	return me.service("").Delete(ctx, id)
+}
+
+func (me *service) SchemaID() string {
+	return ""
+}
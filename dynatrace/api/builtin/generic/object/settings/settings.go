@@ -0,0 +1,126 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package object
+
+import (
+	"encoding/json"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Settings is a settings object of an arbitrary schema.
+// In Terraform the properties are represented by the maps `value` and `secrets`, which contain
+// one entry per top level property of the schema. The typed representation sent to and received
+// from the Settings API is kept in `Object`.
+type Settings struct {
+	SchemaID    string            `json:"-"`
+	Scope       string            `json:"-" scope:"scope"`
+	InsertAfter string            `json:"-"`
+	Value       map[string]string `json:"-"`
+	Secrets     map[string]string `json:"-"`
+	Object      map[string]any    `json:"-"`
+}
+
+func (me *Settings) Name() string {
+	return me.SchemaID
+}
+
+func (me *Settings) MarshalJSON() ([]byte, error) {
+	if me.Object == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(me.Object)
+}
+
+func (me *Settings) UnmarshalJSON(data []byte) error {
+	me.Object = map[string]any{}
+	return json.Unmarshal(data, &me.Object)
+}
+
+func (me *Settings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"schema_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the schema the settings object belongs to, e.g. `builtin:alerting.profile`",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"scope": {
+			Type:        schema.TypeString,
+			Description: "The scope of the settings object. Defaults to `environment`",
+			Optional:    true,
+			Default:     "environment",
+			ForceNew:    true,
+		},
+		"value": {
+			Type:             schema.TypeMap,
+			Description:      "The properties of the settings object. Primitive values are specified as they are, objects and lists need to be encoded via `jsonencode()`",
+			Optional:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			DiffSuppressFunc: SuppressEquivalent,
+		},
+		"secrets": {
+			Type:             schema.TypeMap,
+			Description:      "The properties of type `secret` of the settings object",
+			Optional:         true,
+			Sensitive:        true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			DiffSuppressFunc: SuppressEquivalent,
+		},
+		"insert_after": {
+			Type:        schema.TypeString,
+			Description: "For schemas which allow for ordering you may specify the ID of the settings object that comes before this one. If not specified when creating the settings object will be added to the end of the list. If not specified during update the order will remain untouched",
+			Optional:    true,
+			Computed:    true,
+		},
+	}
+}
+
+// SuppressEquivalent suppresses differences between values which are equal after decoding them from JSON,
+// e.g. numbers like `1.0` and `1` or objects only differing in whitespace or the order of their properties
+func SuppressEquivalent(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	// the number of elements of the map
+	if len(k) > 0 && k[len(k)-1] == '%' {
+		return false
+	}
+	return hcl.JSONStringsEqual(old, new)
+}
+
+func (me *Settings) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"schema_id":    me.SchemaID,
+		"scope":        me.Scope,
+		"value":        me.Value,
+		"secrets":      me.Secrets,
+		"insert_after": me.InsertAfter,
+	})
+}
+
+func (me *Settings) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeAll(map[string]any{
+		"schema_id":    &me.SchemaID,
+		"scope":        &me.Scope,
+		"value":        &me.Value,
+		"secrets":      &me.Secrets,
+		"insert_after": &me.InsertAfter,
+	})
+}
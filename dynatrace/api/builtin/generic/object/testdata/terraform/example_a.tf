@@ -0,0 +1,13 @@
+resource "dynatrace_settings_object" "#name#" {
+  schema_id = "builtin:alerting.profile"
+  value = {
+    name = "#name#"
+    severityRules = jsonencode([
+      {
+        severityLevel        = "AVAILABILITY"
+        delayInMinutes       = 5
+        tagFilterIncludeMode = "NONE"
+      }
+    ])
+  }
+}
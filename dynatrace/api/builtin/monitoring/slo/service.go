@@ -48,6 +48,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*slo.Settin
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	credentials *settings.Credentials
 	client      rest.Client
 }
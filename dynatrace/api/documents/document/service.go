@@ -21,14 +21,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"mime/multipart"
-	"net/http"
-	"os"
-	"sync"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
-	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 
 	documents "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/documents/document/settings"
@@ -44,52 +39,8 @@ type service struct {
 	credentials *settings.Credentials
 }
 
-type MyRoundTripper struct {
-	RoundTripper http.RoundTripper
-}
-
-var lock sync.Mutex
-
-func (rt *MyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	lock.Lock()
-	rest.Logger.Println(req.Method, req.URL)
-	if req.Body != nil {
-		buf := new(bytes.Buffer)
-		io.Copy(buf, req.Body)
-		data := buf.Bytes()
-		rest.Logger.Println("  ", string(data))
-		req.Body = io.NopCloser(bytes.NewBuffer(data))
-	}
-	lock.Unlock()
-	resp, err := rt.RoundTripper.RoundTrip(req)
-	if err != nil {
-		rest.Logger.Println(err.Error())
-	}
-	if resp != nil {
-		if os.Getenv("DYNATRACE_HTTP_RESPONSE") == "true" {
-			if resp.Body != nil {
-				buf := new(bytes.Buffer)
-				io.Copy(buf, resp.Body)
-				data := buf.Bytes()
-				resp.Body = io.NopCloser(bytes.NewBuffer(data))
-				rest.Logger.Println(resp.Status, string(data))
-			} else {
-				rest.Logger.Println(resp.Status)
-			}
-		}
-	}
-	return resp, err
-}
-
 func (me *service) client() *document.Client {
-	if _, ok := http.DefaultClient.Transport.(*MyRoundTripper); !ok {
-		if http.DefaultClient.Transport == nil {
-			http.DefaultClient.Transport = &MyRoundTripper{http.DefaultTransport}
-		} else {
-			http.DefaultClient.Transport = &MyRoundTripper{http.DefaultClient.Transport}
-		}
-	}
-	httpClient := auth.NewOAuthClient(context.TODO(), auth.OauthCredentials{
+	httpClient := auth.NewPlatformClient(me.credentials.HTTPContext(context.TODO()), me.credentials.Automation.PlatformToken, auth.OauthCredentials{
 		ClientID:     me.credentials.Automation.ClientID,
 		ClientSecret: me.credentials.Automation.ClientSecret,
 		TokenURL:     me.credentials.Automation.TokenURL,
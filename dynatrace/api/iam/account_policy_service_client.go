@@ -4,8 +4,8 @@ type AccountPolicyServiceClient struct {
 	PolicyClient *BasePolicyServiceClient
 }
 
-func NewAccountPolicyService(clientID string, accountID string, clientSecret string) *AccountPolicyServiceClient {
-	return &AccountPolicyServiceClient{PolicyClient: NewBasePolicyService(clientID, accountID, clientSecret)}
+func NewAccountPolicyService(clientID string, accountID string, clientSecret string, endpointURL string, tokenURL string) *AccountPolicyServiceClient {
+	return &AccountPolicyServiceClient{PolicyClient: NewBasePolicyService(clientID, accountID, clientSecret, endpointURL, tokenURL)}
 }
 
 func (me *AccountPolicyServiceClient) CREATE(policy *Policy) (string, error) {
@@ -4,8 +4,8 @@ type EnvironmentPolicyServiceClient struct {
 	PolicyClient *BasePolicyServiceClient
 }
 
-func NewEnvironmentPolicyService(clientID string, accountID string, clientSecret string) *EnvironmentPolicyServiceClient {
-	return &EnvironmentPolicyServiceClient{PolicyClient: NewBasePolicyService(clientID, accountID, clientSecret)}
+func NewEnvironmentPolicyService(clientID string, accountID string, clientSecret string, endpointURL string, tokenURL string) *EnvironmentPolicyServiceClient {
+	return &EnvironmentPolicyServiceClient{PolicyClient: NewBasePolicyService(clientID, accountID, clientSecret, endpointURL, tokenURL)}
 }
 
 func (me *EnvironmentPolicyServiceClient) CREATE(policy *Policy) (string, error) {
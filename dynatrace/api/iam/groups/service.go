@@ -17,6 +17,8 @@ type GroupServiceClient struct {
 	clientID     string
 	accountID    string
 	clientSecret string
+	endpointURL  string
+	tokenURL     string
 }
 
 func (me *GroupServiceClient) ClientID() string {
@@ -31,12 +33,20 @@ func (me *GroupServiceClient) ClientSecret() string {
 	return me.clientSecret
 }
 
-func NewGroupService(clientID string, accountID string, clientSecret string) settings.CRUDService[*groups.Group] {
-	return &GroupServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret}
+func (me *GroupServiceClient) EndpointURL() string {
+	return me.endpointURL
+}
+
+func (me *GroupServiceClient) TokenURL() string {
+	return me.tokenURL
+}
+
+func NewGroupService(clientID string, accountID string, clientSecret string, endpointURL string, tokenURL string) settings.CRUDService[*groups.Group] {
+	return &GroupServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret, endpointURL: endpointURL, tokenURL: tokenURL}
 }
 
 func Service(credentials *settings.Credentials) settings.CRUDService[*groups.Group] {
-	return &GroupServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret}
+	return &GroupServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret, endpointURL: credentials.IAM.EndpointURL, tokenURL: credentials.IAM.TokenURL}
 }
 
 func (me *GroupServiceClient) SchemaID() string {
@@ -58,7 +68,7 @@ func (me *GroupServiceClient) Create(ctx context.Context, group *groups.Group) (
 	var responseBytes []byte
 
 	client := iam.NewIAMClient(me)
-	if responseBytes, err = client.POST(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), []*groups.Group{group}, 201, false); err != nil {
+	if responseBytes, err = client.POST(fmt.Sprintf("/iam/v1/accounts/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), []*groups.Group{group}, 201, false); err != nil {
 		return nil, err
 	}
 
@@ -70,7 +80,7 @@ func (me *GroupServiceClient) Create(ctx context.Context, group *groups.Group) (
 	groupName := responseGroups[0].Name
 
 	if len(group.Permissions) > 0 {
-		if _, err = client.PUT(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups/%s/permissions", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), groupID), group.Permissions, 200, false); err != nil {
+		if _, err = client.PUT(fmt.Sprintf("/iam/v1/accounts/%s/groups/%s/permissions", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), groupID), group.Permissions, 200, false); err != nil {
 			return nil, err
 		}
 	}
@@ -82,7 +92,7 @@ func (me *GroupServiceClient) Update(ctx context.Context, uuid string, group *gr
 	var err error
 
 	client := iam.NewIAMClient(me)
-	if _, err = client.PUT(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), uuid), group, 200, false); err != nil {
+	if _, err = client.PUT(fmt.Sprintf("/iam/v1/accounts/%s/groups/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), uuid), group, 200, false); err != nil {
 		return err
 	}
 
@@ -91,7 +101,7 @@ func (me *GroupServiceClient) Update(ctx context.Context, uuid string, group *gr
 	if len(group.Permissions) > 0 {
 		permissions = group.Permissions
 	}
-	if _, err = client.PUT(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups/%s/permissions", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), uuid), permissions, 200, false); err != nil {
+	if _, err = client.PUT(fmt.Sprintf("/iam/v1/accounts/%s/groups/%s/permissions", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), uuid), permissions, 200, false); err != nil {
 		return err
 	}
 
@@ -158,7 +168,7 @@ func (me *GroupServiceClient) listUnguarded() ([]*ListGroup, error) {
 	client := iam.NewIAMClient(me)
 	var response ListGroupsResponse
 	accountID := strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")
-	if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups", accountID), 200, false, &response); err != nil {
+	if err = iam.GET(client, fmt.Sprintf("/iam/v1/accounts/%s/groups", accountID), 200, false, &response); err != nil {
 		return nil, err
 	}
 	return response.Items, nil
@@ -174,7 +184,7 @@ func (me *GroupServiceClient) Get(ctx context.Context, id string, v *groups.Grou
 			accountID := strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")
 			client := iam.NewIAMClient(me)
 			var groupStub ListGroup
-			if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups/%s/permissions", accountID, id), 200, false, &groupStub); err != nil {
+			if err = iam.GET(client, fmt.Sprintf("/iam/v1/accounts/%s/groups/%s/permissions", accountID, id), 200, false, &groupStub); err != nil {
 				return err
 			}
 
@@ -191,6 +201,6 @@ func (me *GroupServiceClient) Get(ctx context.Context, id string, v *groups.Grou
 }
 
 func (me *GroupServiceClient) Delete(ctx context.Context, id string) error {
-	_, err := iam.NewIAMClient(me).DELETE(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/groups/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), id), 200, false)
+	_, err := iam.NewIAMClient(me).DELETE(fmt.Sprintf("/iam/v1/accounts/%s/groups/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), id), 200, false)
 	return err
 }
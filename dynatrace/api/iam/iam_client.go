@@ -153,6 +153,11 @@ func (me *iamClient) request(url string, method string, expectedResponseCodes []
 func (me *iamClient) _request(url string, method string, expectedResponseCodes []int, forceNewBearer bool, forceNewBearerRetryCount int, payload any, headers map[string]string) ([]byte, error) {
 	// httplog(fmt.Sprintf("[%s] %s", method, url))
 
+	// services are addressing the IAM API with paths relative to the configured endpoint
+	if strings.HasPrefix(url, "/") {
+		url = endpointURL(me.auth) + url
+	}
+
 	num504Retries := 0
 
 	for {
@@ -162,15 +167,9 @@ func (me *iamClient) _request(url string, method string, expectedResponseCodes [
 		var responseBytes []byte
 		var requestBody []byte
 
-		rest.Logger.Println(method, url)
-
 		if requestBody, err = json.Marshal(payload); err != nil {
 			return nil, err
 		}
-		if payload != nil {
-			rest.Logger.Println("  ", string(requestBody))
-		}
-
 		var body io.Reader
 
 		if payload != nil {
@@ -189,14 +188,13 @@ func (me *iamClient) _request(url string, method string, expectedResponseCodes [
 			httpRequest.Header.Add(k, v)
 		}
 
-		if httpResponse, err = http.DefaultClient.Do(httpRequest); err != nil {
+		if httpResponse, err = rest.HTTPClient().Do(httpRequest); err != nil {
 			return nil, err
 		}
 
 		if responseBytes, err = io.ReadAll(httpResponse.Body); err != nil {
 			return nil, err
 		}
-		rest.Logger.Println("  ", httpResponse.StatusCode, string(responseBytes))
 
 		if httpResponse.StatusCode == 504 {
 			// httplog("-------------------- FIVE-O-FOUR --------------------")
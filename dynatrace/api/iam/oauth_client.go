@@ -18,6 +18,30 @@ type Authenticator interface {
 	ClientID() string
 	AccountID() string
 	ClientSecret() string
+	// EndpointURL is the base URL of the IAM API. An empty URL refers to `DefaultEndpointURL`
+	EndpointURL() string
+	// TokenURL is the URL bearer tokens are getting requested from. An empty URL refers to `DefaultTokenURL`
+	TokenURL() string
+}
+
+// DefaultEndpointURL is the base URL of the IAM API, unless configured otherwise via `iam_endpoint_url`
+const DefaultEndpointURL = "https://api.dynatrace.com"
+
+// DefaultTokenURL is the URL bearer tokens for the IAM API are getting requested from, unless configured otherwise via `iam_token_url`
+const DefaultTokenURL = "https://sso.dynatrace.com/sso/oauth2/token"
+
+func endpointURL(auth Authenticator) string {
+	if endpointURL := strings.TrimSuffix(auth.EndpointURL(), "/"); len(endpointURL) > 0 {
+		return endpointURL
+	}
+	return DefaultEndpointURL
+}
+
+func tokenURL(auth Authenticator) string {
+	if tokenURL := auth.TokenURL(); len(tokenURL) > 0 {
+		return tokenURL
+	}
+	return DefaultTokenURL
 }
 
 var tokens = map[string]string{}
@@ -42,12 +66,12 @@ func getBearer(auth Authenticator, forceNew bool) (string, error) {
 	var err error
 
 	if !forceNew {
-		if token, found := tokens[auth.ClientID()+auth.AccountID()]; found {
+		if token, found := tokens[tokenURL(auth)+auth.ClientID()+auth.AccountID()]; found {
 			return token, nil
 		}
 	}
 
-	httpClient := http.DefaultClient
+	httpClient := rest.HTTPClient()
 
 	payloadStr := fmt.Sprintf(
 		"grant_type=client_credentials&client_id=%s&client_secret=%s",
@@ -56,7 +80,7 @@ func getBearer(auth Authenticator, forceNew bool) (string, error) {
 	)
 	payload := strings.NewReader(payloadStr)
 
-	if httpReq, err = http.NewRequest(http.MethodPost, "https://sso.dynatrace.com/sso/oauth2/token", payload); err != nil {
+	if httpReq, err = http.NewRequest(http.MethodPost, tokenURL(auth), payload); err != nil {
 		return "", err
 	}
 	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -73,7 +97,7 @@ func getBearer(auth Authenticator, forceNew bool) (string, error) {
 			url.QueryEscape(auth.ClientID()),
 			url.QueryEscape("<hidden>"),
 		)
-		rest.Logger.Println("POST https://sso.dynatrace.com/sso/oauth2/token")
+		rest.Logger.Println("POST " + tokenURL(auth))
 		rest.Logger.Println("  " + debugPayloadStr)
 		rest.Logger.Println("  -> " + string(body))
 	}
@@ -84,6 +108,6 @@ func getBearer(auth Authenticator, forceNew bool) (string, error) {
 	if err = json.Unmarshal(body, &response); err != nil {
 		return "", err
 	}
-	tokens[auth.ClientID()+auth.AccountID()] = response.AccessToken
+	tokens[tokenURL(auth)+auth.ClientID()+auth.AccountID()] = response.AccessToken
 	return response.AccessToken, nil
 }
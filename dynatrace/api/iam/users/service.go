@@ -18,6 +18,8 @@ type UserServiceClient struct {
 	clientID     string
 	accountID    string
 	clientSecret string
+	endpointURL  string
+	tokenURL     string
 }
 
 func (me *UserServiceClient) ClientID() string {
@@ -32,12 +34,20 @@ func (me *UserServiceClient) ClientSecret() string {
 	return me.clientSecret
 }
 
-func NewUserService(clientID string, accountID string, clientSecret string) *UserServiceClient {
-	return &UserServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret}
+func (me *UserServiceClient) EndpointURL() string {
+	return me.endpointURL
+}
+
+func (me *UserServiceClient) TokenURL() string {
+	return me.tokenURL
+}
+
+func NewUserService(clientID string, accountID string, clientSecret string, endpointURL string, tokenURL string) *UserServiceClient {
+	return &UserServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret, endpointURL: endpointURL, tokenURL: tokenURL}
 }
 
 func Service(credentials *settings.Credentials) settings.CRUDService[*users.User] {
-	return &UserServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret}
+	return &UserServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret, endpointURL: credentials.IAM.EndpointURL, tokenURL: credentials.IAM.TokenURL}
 }
 
 func (me *UserServiceClient) SchemaID() string {
@@ -48,7 +58,7 @@ func (me *UserServiceClient) Create(ctx context.Context, user *users.User) (*api
 	var err error
 
 	client := iam.NewIAMClient(me)
-	if _, err = client.POST(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), user, 201, false); err != nil {
+	if _, err = client.POST(fmt.Sprintf("/iam/v1/accounts/%s/users", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), user, 201, false); err != nil {
 		if err.Error() == "User already exists" {
 			if err = me.Update(ctx, user.Email, user); err != nil {
 				return nil, err
@@ -62,7 +72,7 @@ func (me *UserServiceClient) Create(ctx context.Context, user *users.User) (*api
 	if len(user.Groups) > 0 {
 		groups = user.Groups
 	}
-	if _, err = client.PUT(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
+	if _, err = client.PUT(fmt.Sprintf("/iam/v1/accounts/%s/users/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
 		return nil, err
 	}
 
@@ -85,7 +95,7 @@ func (me *UserServiceClient) Get(ctx context.Context, email string, v *users.Use
 
 	client := iam.NewIAMClient(me)
 
-	if responseBytes, err = client.GET(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), email), 200, false); err != nil {
+	if responseBytes, err = client.GET(fmt.Sprintf("/iam/v1/accounts/%s/users/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), email), 200, false); err != nil {
 		if err != nil && strings.Contains(err.Error(), fmt.Sprintf("User %s not found", email)) {
 			return rest.Error{Code: 404, Message: err.Error()}
 		}
@@ -99,7 +109,7 @@ func (me *UserServiceClient) Get(ctx context.Context, email string, v *users.Use
 	v.Email = email
 	v.Groups = []string{}
 	v.UID = response.UID
-	groupService := groups.NewGroupService(me.clientID, me.accountID, me.clientID)
+	groupService := groups.NewGroupService(me.clientID, me.accountID, me.clientSecret, me.endpointURL, me.tokenURL)
 	var visibleGroupIDs api.Stubs
 	if visibleGroupIDs, err = groupService.List(ctx); err != nil {
 		return err
@@ -123,7 +133,7 @@ func (me *UserServiceClient) Update(ctx context.Context, email string, user *use
 	if len(user.Groups) > 0 {
 		groups = user.Groups
 	}
-	if _, err = iam.NewIAMClient(me).PUT(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
+	if _, err = iam.NewIAMClient(me).PUT(fmt.Sprintf("/iam/v1/accounts/%s/users/%s/groups", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), user.Email), groups, 200, false); err != nil {
 		return err
 	}
 
@@ -144,7 +154,7 @@ func (me *UserServiceClient) List(ctx context.Context) (api.Stubs, error) {
 	var err error
 	var responseBytes []byte
 
-	if responseBytes, err = iam.NewIAMClient(me).GET(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), 200, false); err != nil {
+	if responseBytes, err = iam.NewIAMClient(me).GET(fmt.Sprintf("/iam/v1/accounts/%s/users", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), 200, false); err != nil {
 		return nil, err
 	}
 
@@ -160,7 +170,7 @@ func (me *UserServiceClient) List(ctx context.Context) (api.Stubs, error) {
 }
 
 func (me *UserServiceClient) Delete(ctx context.Context, email string) error {
-	_, err := iam.NewIAMClient(me).DELETE(fmt.Sprintf("https://api.dynatrace.com/iam/v1/accounts/%s/users/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), email), 200, false)
+	_, err := iam.NewIAMClient(me).DELETE(fmt.Sprintf("/iam/v1/accounts/%s/users/%s", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:"), email), 200, false)
 	if err != nil && strings.Contains(err.Error(), fmt.Sprintf("User %s not found", email)) {
 		return nil
 	}
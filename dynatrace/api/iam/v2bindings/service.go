@@ -16,6 +16,8 @@ type BindingServiceClient struct {
 	clientID     string
 	accountID    string
 	clientSecret string
+	endpointURL  string
+	tokenURL     string
 }
 
 func (me *BindingServiceClient) ClientID() string {
@@ -30,12 +32,20 @@ func (me *BindingServiceClient) ClientSecret() string {
 	return me.clientSecret
 }
 
-func NewPolicyService(clientID string, accountID string, clientSecret string) *BindingServiceClient {
-	return &BindingServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret}
+func (me *BindingServiceClient) EndpointURL() string {
+	return me.endpointURL
+}
+
+func (me *BindingServiceClient) TokenURL() string {
+	return me.tokenURL
+}
+
+func NewPolicyService(clientID string, accountID string, clientSecret string, endpointURL string, tokenURL string) *BindingServiceClient {
+	return &BindingServiceClient{clientID: clientID, accountID: accountID, clientSecret: clientSecret, endpointURL: endpointURL, tokenURL: tokenURL}
 }
 
 func Service(credentials *settings.Credentials) settings.CRUDService[*bindings.PolicyBinding] {
-	return &BindingServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret}
+	return &BindingServiceClient{clientID: credentials.IAM.ClientID, accountID: credentials.IAM.AccountID, clientSecret: credentials.IAM.ClientSecret, endpointURL: credentials.IAM.EndpointURL, tokenURL: credentials.IAM.TokenURL}
 }
 
 func (me *BindingServiceClient) SchemaID() string {
@@ -102,7 +112,7 @@ func (me *BindingServiceClient) Get(ctx context.Context, id string, v *bindings.
 	policyUUIDStruct := struct {
 		PolicyUuids []string `json:"policyUuids"`
 	}{}
-	if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/%s/%s/bindings/groups/%s", levelType, levelID, groupID), 200, false, &policyUUIDStruct); err != nil {
+	if err = iam.GET(client, fmt.Sprintf("/iam/v1/repo/%s/%s/bindings/groups/%s", levelType, levelID, groupID), 200, false, &policyUUIDStruct); err != nil {
 		return err
 	}
 	if levelType == "account" {
@@ -115,7 +125,7 @@ func (me *BindingServiceClient) Get(ctx context.Context, id string, v *bindings.
 
 	for _, policyID := range policyUUIDStruct.PolicyUuids {
 		var bindingsResponse BindingsResponse
-		if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyID, groupID), 200, false, &bindingsResponse); err != nil {
+		if err = iam.GET(client, fmt.Sprintf("/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyID, groupID), 200, false, &bindingsResponse); err != nil {
 			return err
 		}
 		if len(bindingsResponse.PolicyBindings) == 0 {
@@ -173,7 +183,7 @@ func (me *BindingServiceClient) Update(ctx context.Context, id string, v *bindin
 
 	for _, policy := range policiesList {
 		policyUUID, _, _, _ := policies.SplitID(policy.ID, levelType, levelID)
-		if _, err = client.DELETE_MULTI_RESPONSE(fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), []int{204, 400, 404}, false); err != nil {
+		if _, err = client.DELETE_MULTI_RESPONSE(fmt.Sprintf("/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), []int{204, 400, 404}, false); err != nil {
 			return err
 		}
 	}
@@ -186,7 +196,7 @@ func (me *BindingServiceClient) Update(ctx context.Context, id string, v *bindin
 			Parameters: policy.Parameters,
 			Metadata:   policy.Metadata,
 		}
-		if _, err = client.POST(fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), payload, 204, false); err != nil {
+		if _, err = client.POST(fmt.Sprintf("/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), payload, 204, false); err != nil {
 			return err
 		}
 	}
@@ -221,7 +231,7 @@ func (me *BindingServiceClient) FetchAccountBindings() chan *api.Stub {
 		var response ListPolicyBindingsResponse
 		client := iam.NewIAMClient(me)
 
-		if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/account/%s/bindings", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), 200, false, &response); err != nil {
+		if err = iam.GET(client, fmt.Sprintf("/iam/v1/repo/account/%s/bindings", strings.TrimPrefix(me.AccountID(), "urn:dtaccount:")), 200, false, &response); err != nil {
 			return
 		}
 
@@ -260,7 +270,7 @@ func (me *BindingServiceClient) FetchEnvironmentBindings() chan *api.Stub {
 		var stubs api.Stubs
 		for _, environmentID := range environmentIDs {
 			var response ListPolicyBindingsResponse
-			if err = iam.GET(client, fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/environment/%s/bindings", environmentID), 200, false, &response); err != nil {
+			if err = iam.GET(client, fmt.Sprintf("/iam/v1/repo/environment/%s/bindings", environmentID), 200, false, &response); err != nil {
 				return
 			}
 
@@ -336,7 +346,7 @@ func (me *BindingServiceClient) Delete(ctx context.Context, id string) error {
 		policyUUIDs[policyUUID] = policyUUID
 	}
 	for policyUUID := range policyUUIDs {
-		if _, err = iam.NewIAMClient(me).DELETE(fmt.Sprintf("https://api.dynatrace.com/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), 204, false); err != nil {
+		if _, err = iam.NewIAMClient(me).DELETE(fmt.Sprintf("/iam/v1/repo/%s/%s/bindings/%s/%s", levelType, levelID, policyUUID, groupID), 204, false); err != nil {
 			return err
 		}
 	}
@@ -20,7 +20,7 @@ package buckets
 import (
 	"context"
 	"encoding/json"
-	"io"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -32,10 +32,9 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
 
 	buckets "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/platform/buckets/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/monaco/pkg/client/auth"
 	crest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
-	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients"
 	bucket "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
-	"golang.org/x/oauth2/clientcredentials"
 )
 
 func Service(credentials *settings.Credentials) settings.CRUDService[*buckets.Bucket] {
@@ -46,46 +45,14 @@ type service struct {
 	credentials *settings.Credentials
 }
 
-var httpListener = &crest.HTTPListener{
-	Callback: func(response crest.RequestResponse) {
-		if response.Request != nil {
-			if response.Request.URL != nil {
-				if response.Request.Body != nil {
-					body, _ := io.ReadAll(response.Request.Body)
-					rest.Logger.Println(response.Request.Method, response.Request.URL.String()+"\n    "+string(body))
-				} else {
-					rest.Logger.Println(response.Request.Method, response.Request.URL)
-				}
-			}
-		}
-		if response.Response != nil {
-			if response.Response.Body != nil {
-				if os.Getenv("DYNATRACE_HTTP_RESPONSE") == "true" {
-					body, _ := io.ReadAll(response.Response.Body)
-					if body != nil {
-						rest.Logger.Println(response.Response.StatusCode, string(body))
-					} else {
-						rest.Logger.Println(response.Response.StatusCode)
-					}
-				}
-			}
-		}
-	},
-}
-
 func (me *service) client() *bucket.Client {
-	factory := clients.Factory().
-		WithUserAgent("Dynatrace Terraform Provider").
-		WithEnvironmentURL(me.credentials.Automation.EnvironmentURL).
-		WithOAuthCredentials(clientcredentials.Config{
-			ClientID:     me.credentials.Automation.ClientID,
-			ClientSecret: me.credentials.Automation.ClientSecret,
-			TokenURL:     me.credentials.Automation.TokenURL,
-		}).
-		WithHTTPListener(httpListener)
-
-	bucketClient, _ := factory.BucketClient()
-	return bucketClient
+	u, _ := url.Parse(me.credentials.Automation.EnvironmentURL)
+	httpClient := auth.NewPlatformClient(me.credentials.HTTPContext(context.TODO()), me.credentials.Automation.PlatformToken, auth.OauthCredentials{
+		ClientID:     me.credentials.Automation.ClientID,
+		ClientSecret: me.credentials.Automation.ClientSecret,
+		TokenURL:     me.credentials.Automation.TokenURL,
+	})
+	return bucket.NewClient(crest.NewClient(u, httpClient))
 }
 
 func (me *service) Get(ctx context.Context, id string, v *buckets.Bucket) (err error) {
@@ -0,0 +1,69 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package api
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"sync"
+)
+
+// bundledSchemas contains the `schema.json` files of the Settings 2.0 schemas the provider has been built against
+//
+//go:embed builtin/*/schema.json builtin/*/*/schema.json builtin/*/*/*/schema.json builtin/*/*/*/*/schema.json
+//go:embed app/*/*/schema.json app/*/*/*/schema.json
+var bundledSchemas embed.FS
+
+var schemaFiles map[string]string
+var schemaFilesLock sync.Mutex
+
+// BundledSchema returns the contents of the `schema.json` file bundled for the given Settings 2.0 schema.
+// The second return value is `false` in case no schema with that ID has been bundled.
+func BundledSchema(schemaID string) ([]byte, bool) {
+	schemaFilesLock.Lock()
+	defer schemaFilesLock.Unlock()
+	if schemaFiles == nil {
+		schemaFiles = map[string]string{}
+		fs.WalkDir(bundledSchemas, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := bundledSchemas.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			var header struct {
+				SchemaID string `json:"schemaId"`
+			}
+			if json.Unmarshal(data, &header) == nil && len(header.SchemaID) > 0 {
+				schemaFiles[header.SchemaID] = path
+			}
+			return nil
+		})
+	}
+	path, found := schemaFiles[schemaID]
+	if !found {
+		return nil, false
+	}
+	data, err := bundledSchemas.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
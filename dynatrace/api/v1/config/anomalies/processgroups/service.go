@@ -40,6 +40,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*processgro
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	credentials *settings.Credentials
 	client      rest.Client
 }
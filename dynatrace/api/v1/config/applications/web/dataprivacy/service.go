@@ -44,6 +44,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*dataprivac
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	schemaID      string
 	client        rest.Client
 	webAppService settings.CRUDService[*web.Application]
@@ -45,6 +45,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*errors.Rul
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	client        rest.Client
 	webAppService settings.CRUDService[*web.Application]
 }
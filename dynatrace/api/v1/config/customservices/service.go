@@ -34,6 +34,7 @@ import (
 const SchemaID = "v1:config:custom-services"
 
 type service struct {
+	settings.ValidatorEndpoint
 	client rest.Client
 }
 
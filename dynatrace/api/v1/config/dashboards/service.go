@@ -75,6 +75,11 @@ func (me *service) Validate(v *dashboards.Dashboard) error {
 	return nil
 }
 
+// ValidatesServerSide signals whether `Validate` sends the settings to the validator endpoint of the Dynatrace environment
+func (me *service) ValidatesServerSide() bool {
+	return settings.ValidatesServerSide(me.service)
+}
+
 func (me *service) Create(ctx context.Context, v *dashboards.Dashboard) (*api.Stub, error) {
 	var err error
 	var data []byte
@@ -45,6 +45,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*sharing.Da
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	client           rest.Client
 	dashboardService settings.CRUDService[*dashboards.JSONDashboard]
 }
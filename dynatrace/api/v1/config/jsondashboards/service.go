@@ -172,6 +172,11 @@ func (me *service) Validate(v *dashboards.JSONDashboard) error {
 	return nil
 }
 
+// ValidatesServerSide signals whether `Validate` sends the settings to the validator endpoint of the Dynatrace environment
+func (me *service) ValidatesServerSide() bool {
+	return settings.ValidatesServerSide(me.service)
+}
+
 func (me *service) Create(ctx context.Context, v *dashboards.JSONDashboard) (*api.Stub, error) {
 	doCreateService := true
 	var stub *api.Stub = nil
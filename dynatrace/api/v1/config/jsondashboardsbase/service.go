@@ -76,6 +76,11 @@ func (me *service) Validate(v *dashboardsbase.JSONDashboardBase) error {
 	return nil
 }
 
+// ValidatesServerSide signals whether `Validate` sends the settings to the validator endpoint of the Dynatrace environment
+func (me *service) ValidatesServerSide() bool {
+	return JSON_DASHBOARD_BASE_PLUS && settings.ValidatesServerSide(me.service)
+}
+
 func (me *service) Create(ctx context.Context, v *dashboardsbase.JSONDashboardBase) (*api.Stub, error) {
 	if JSON_DASHBOARD_BASE_PLUS {
 		return me.service.Create(ctx, v.EnrichRequireds())
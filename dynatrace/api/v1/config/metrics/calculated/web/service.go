@@ -38,6 +38,7 @@ func Service(credentials *settings.Credentials) settings.CRUDService[*mysettings
 }
 
 type service struct {
+	settings.ValidatorEndpoint
 	client rest.Client
 }
 
@@ -79,6 +79,10 @@ func runExport() (err error) {
 		return err
 	}
 
+	if environment.Flags.SchemaCheck {
+		return environment.WriteSchemaCheckReport()
+	}
+
 	err = environment.RunQuickInit()
 	if err != nil {
 		return err
@@ -88,6 +92,14 @@ func runExport() (err error) {
 		return err
 	}
 
+	if err = environment.WriteDriftReport(); err != nil {
+		return err
+	}
+
+	if err = environment.WriteDiffCheckReport(); err != nil {
+		return err
+	}
+
 	err = environment.FinishExport()
 	if err != nil {
 		return err
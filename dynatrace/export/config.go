@@ -0,0 +1,263 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hclgen"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// ExportConfig is the contents of the file specified via `-config`.
+// Supported are HCL (`.hcl`) and JSON (`.json`) files.
+//
+// Precedence: command line flags which have been specified explicitly win over
+// environment variables, which in turn win over the settings in this file.
+//
+//	target_folder = "configuration"
+//	include       = ["dynatrace_management_zone_v2"]
+//	exclude       = ["dynatrace_json_dashboard"]
+//
+//	resource "dynatrace_alerting" {
+//	  ids = ["vu9U3hXa3q0AAAAB..."]
+//	}
+//
+//	resource "dynatrace_management_zone_v2" {
+//	  filters = ["name~^team-a-"]
+//	}
+//
+//	naming {
+//	  shorter_names = true
+//	}
+type ExportConfig struct {
+	TargetFolder        *string                 `hcl:"target_folder,optional"`
+	CleanTargetFolder   *bool                   `hcl:"clean_target_folder,optional"`
+	Parallel            *bool                   `hcl:"parallel,optional"`
+	FollowReferences    *bool                   `hcl:"ref,optional"`
+	DataSources         *bool                   `hcl:"datasources,optional"`
+	PersistIDs          *bool                   `hcl:"id,optional"`
+	Migrate             *bool                   `hcl:"migrate,optional"`
+	HardLinks           *bool                   `hcl:"link,optional"`
+	Flat                *bool                   `hcl:"flat,optional"`
+	ImportState         *bool                   `hcl:"import_state,optional"`
+	SkipTerraformInit   *bool                   `hcl:"skip_terraform_init,optional"`
+	Incremental         *bool                   `hcl:"incremental,optional"`
+	Layout              *string                 `hcl:"layout,optional"`
+	IgnoreResourcesFile *string                 `hcl:"ignore_resources_file,optional"`
+	Include             []string                `hcl:"include,optional"`
+	Exclude             []string                `hcl:"exclude,optional"`
+	Resources           []*ExportConfigResource `hcl:"resource,block"`
+	Naming              *ExportConfigNaming     `hcl:"naming,block"`
+	Output              *ExportConfigOutput     `hcl:"output,block"`
+	State               *ExportConfigState      `hcl:"state,block"`
+}
+
+// ExportConfigResource restricts the export of a resource type to the given IDs
+// and/or the resources matching the given filters (`<property><operator><value>`)
+type ExportConfigResource struct {
+	Type    string   `hcl:"type,label"`
+	IDs     []string `hcl:"ids,optional"`
+	Filters []string `hcl:"filters,optional"`
+}
+
+type ExportConfigNaming struct {
+	ShorterNames *bool `hcl:"shorter_names,optional"`
+	ReplaceDash  *bool `hcl:"replace_dash,optional"`
+}
+
+type ExportConfigOutput struct {
+	Heredoc *bool `hcl:"heredoc,optional"`
+	Format  *bool `hcl:"format,optional"`
+	JSON    *bool `hcl:"json,optional"`
+}
+
+type ExportConfigState struct {
+	PrevStateOn         *bool   `hcl:"prev_state_on,optional"`
+	PrevStatePathThis   *string `hcl:"prev_state_path_this,optional"`
+	PrevStatePathLinked *string `hcl:"prev_state_path_linked,optional"`
+	ImportStatePath     *string `hcl:"import_state_path,optional"`
+}
+
+func LoadExportConfig(fileName string) (*ExportConfig, error) {
+	var config ExportConfig
+	if err := hclsimple.DecodeFile(fileName, nil, &config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid export configuration `%s`: %s", fileName, err.Error())
+	}
+	return &config, nil
+}
+
+func (me *ExportConfig) Validate() error {
+	included := map[string]bool{}
+	for _, resourceType := range me.Include {
+		if key, _ := ValidateResource(resourceType); len(key) == 0 {
+			return fmt.Errorf("unknown resource `%s` in `include`", resourceType)
+		}
+		included[resourceType] = true
+	}
+	for _, resource := range me.Resources {
+		if key, _ := ValidateResource(resource.Type); len(key) == 0 {
+			return fmt.Errorf("unknown resource `%s`", resource.Type)
+		}
+		for _, filter := range resource.Filters {
+			if _, err := ParseResourceFilter(resource.Type + ":" + filter); err != nil {
+				return err
+			}
+		}
+		included[resource.Type] = true
+	}
+	for _, resourceType := range me.Exclude {
+		if key, _ := ValidateResource(resourceType); len(key) == 0 {
+			return fmt.Errorf("unknown resource `%s` in `exclude`", resourceType)
+		}
+		if included[resourceType] {
+			return fmt.Errorf("resource `%s` is both included and excluded", resourceType)
+		}
+	}
+	if me.Layout != nil {
+		if _, err := NewLayout(*me.Layout); err != nil {
+			return err
+		}
+	}
+	if me.Migrate != nil && *me.Migrate && me.FollowReferences != nil && *me.FollowReferences {
+		return fmt.Errorf("`ref` and `migrate` are mutually exclusive")
+	}
+	return nil
+}
+
+// TailArgs returns the resources to export in the same format as the command line expects them (`resource_type` or `resource_type=id`)
+func (me *ExportConfig) TailArgs() []string {
+	tailArgs := []string{}
+	tailArgs = append(tailArgs, me.Include...)
+	for _, resource := range me.Resources {
+		for _, filter := range resource.Filters {
+			tailArgs = append(tailArgs, resource.Type+":"+filter)
+		}
+		if len(resource.IDs) == 0 {
+			if len(resource.Filters) == 0 {
+				tailArgs = append(tailArgs, resource.Type)
+			}
+			continue
+		}
+		for _, id := range resource.IDs {
+			tailArgs = append(tailArgs, resource.Type+"="+id)
+		}
+	}
+	return tailArgs
+}
+
+// ApplyFlags sets the flags configured in this file, unless they have been specified explicitly on the command line
+func (me *ExportConfig) ApplyFlags(flags *Flags) {
+	applyFlag := func(name string, target *bool, value *bool) {
+		if value == nil || flags.explicit[name] {
+			return
+		}
+		*target = *value
+	}
+	applyFlag("ref", &flags.FollowReferences, me.FollowReferences)
+	applyFlag("datasources", &flags.DataSources, me.DataSources)
+	applyFlag("id", &flags.PersistIDs, me.PersistIDs)
+	applyFlag("migrate", &flags.FlagMigrationOutput, me.Migrate)
+	applyFlag("link", &flags.FlagHardLinks, me.HardLinks)
+	applyFlag("flat", &flags.Flat, me.Flat)
+	applyFlag("skip-terraform-init", &flags.SkipTerraformInit, me.SkipTerraformInit)
+	applyFlag("incremental", &flags.Incremental, me.Incremental)
+	if me.Output != nil {
+		applyFlag("json", &flags.JSON, me.Output.JSON)
+	}
+	if me.Layout != nil && !flags.explicit["layout"] {
+		flags.Layout = *me.Layout
+	}
+	if me.ImportState != nil && !flags.explicit["import-state"] && !flags.explicit["import-state-v2"] {
+		flags.ImportStateV2 = *me.ImportState
+	}
+}
+
+// ApplyEnv sets the options which otherwise are getting configured via environment variables,
+// unless the corresponding environment variable is set
+func (me *ExportConfig) ApplyEnv() {
+	applyBool := func(envVar string, target *bool, value *bool) {
+		if value == nil {
+			return
+		}
+		if _, found := os.LookupEnv(envVar); found {
+			return
+		}
+		*target = *value
+	}
+	applyString := func(envVar string, target *string, value *string) {
+		if value == nil {
+			return
+		}
+		if _, found := os.LookupEnv(envVar); found {
+			return
+		}
+		*target = *value
+	}
+
+	applyBool("DYNATRACE_PARALLEL", &PARALLEL, me.Parallel)
+	applyString("DYNATRACE_EXPORT_IGNORE_RESOURCES", &EXPORT_IGNORE_RESOURCES_PATH, me.IgnoreResourcesFile)
+	if me.Naming != nil {
+		applyBool("DYNATRACE_SHORTER_NAMES", &SHORTER_NAMES, me.Naming.ShorterNames)
+		applyBool("DYNATRACE_NAME_REPLACE_DASH", &NAME_REPLACE_DASH, me.Naming.ReplaceDash)
+	}
+	if me.Output != nil {
+		if me.Output.Format != nil {
+			if _, found := os.LookupEnv("DYNATRACE_HCL_NO_FORMAT"); !found {
+				HCL_NO_FORMAT = !*me.Output.Format
+			}
+		}
+		if me.Output.Heredoc != nil {
+			if _, found := os.LookupEnv("DYNATRACE_HEREDOC"); !found {
+				hclgen.SetHeredoc(*me.Output.Heredoc)
+			}
+		}
+	}
+	if me.State != nil {
+		applyBool("DYNATRACE_PREV_STATE_ON", &PREV_STATE_ON, me.State.PrevStateOn)
+		applyString("DYNATRACE_PREV_STATE_PATH_THIS", &PREV_STATE_PATH_THIS, me.State.PrevStatePathThis)
+		applyString("DYNATRACE_PREV_STATE_PATH_LINKED", &PREV_STATE_PATH_LINKED, me.State.PrevStatePathLinked)
+		applyString("DYNATRACE_IMPORT_STATE_PATH", &IMPORT_STATE_PATH, me.State.ImportStatePath)
+	}
+}
+
+// GetTargetFolder resolves the output folder. `DYNATRACE_TARGET_FOLDER` wins over the config file
+func (me *ExportConfig) GetTargetFolder() string {
+	if targetFolder := os.Getenv("DYNATRACE_TARGET_FOLDER"); len(targetFolder) > 0 {
+		return targetFolder
+	}
+	if me != nil && me.TargetFolder != nil {
+		return *me.TargetFolder
+	}
+	return ""
+}
+
+// IsCleanTargetFolder resolves whether the output folder should get cleaned. `DYNATRACE_CLEAN_TARGET_FOLDER` wins over the config file
+func (me *ExportConfig) IsCleanTargetFolder() bool {
+	if value, found := os.LookupEnv("DYNATRACE_CLEAN_TARGET_FOLDER"); found {
+		return value == "true"
+	}
+	if me != nil && me.CleanTargetFolder != nil {
+		return *me.CleanTargetFolder
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package export
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestApplyFlags(t *testing.T) {
+	enabled := true
+	disabled := false
+	layout := LayoutKeys.ManagementZone
+
+	tests := []struct {
+		name     string
+		config   ExportConfig
+		flags    Flags
+		expected Flags
+	}{
+		{
+			name:     "unset in file",
+			config:   ExportConfig{},
+			flags:    Flags{FollowReferences: true, Layout: LayoutKeys.ResourceType},
+			expected: Flags{FollowReferences: true, Layout: LayoutKeys.ResourceType},
+		},
+		{
+			name:     "file wins over defaults",
+			config:   ExportConfig{FollowReferences: &enabled, Flat: &enabled, Layout: &layout, ImportState: &enabled, Output: &ExportConfigOutput{JSON: &enabled}},
+			flags:    Flags{Layout: LayoutKeys.ResourceType},
+			expected: Flags{FollowReferences: true, Flat: true, Layout: LayoutKeys.ManagementZone, ImportStateV2: true, JSON: true},
+		},
+		{
+			name:     "file disables defaults",
+			config:   ExportConfig{DataSources: &disabled},
+			flags:    Flags{DataSources: true},
+			expected: Flags{DataSources: false},
+		},
+		{
+			name:     "explicit flags win over file",
+			config:   ExportConfig{FollowReferences: &enabled, Flat: &enabled, Layout: &layout, ImportState: &disabled},
+			flags:    Flags{Layout: LayoutKeys.Owner, ImportStateV2: true, explicit: map[string]bool{"ref": true, "layout": true, "import-state-v2": true}},
+			expected: Flags{Flat: true, Layout: LayoutKeys.Owner, ImportStateV2: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.ApplyFlags(&test.flags)
+			test.flags.explicit = nil
+			if !reflect.DeepEqual(test.flags, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, test.flags)
+			}
+		})
+	}
+}
+
+// unsetenv removes the given environment variable for the duration of the test
+func unsetenv(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "")
+	os.Unsetenv(name)
+}
+
+func TestApplyEnv(t *testing.T) {
+	defer func(parallel bool, shorterNames bool, replaceDash bool, prevStatePath string) {
+		PARALLEL, SHORTER_NAMES, NAME_REPLACE_DASH, PREV_STATE_PATH_THIS = parallel, shorterNames, replaceDash, prevStatePath
+	}(PARALLEL, SHORTER_NAMES, NAME_REPLACE_DASH, PREV_STATE_PATH_THIS)
+
+	enabled := true
+	disabled := false
+	prevStatePath := "state.json"
+	config := ExportConfig{
+		Parallel: &disabled,
+		Naming:   &ExportConfigNaming{ShorterNames: &enabled, ReplaceDash: &enabled},
+		State:    &ExportConfigState{PrevStatePathThis: &prevStatePath},
+	}
+
+	PARALLEL, SHORTER_NAMES, NAME_REPLACE_DASH, PREV_STATE_PATH_THIS = true, false, false, ""
+	unsetenv(t, "DYNATRACE_PARALLEL")
+	unsetenv(t, "DYNATRACE_PREV_STATE_PATH_THIS")
+	// an environment variable wins over the file, even if it's set to its default value
+	t.Setenv("DYNATRACE_SHORTER_NAMES", "false")
+	unsetenv(t, "DYNATRACE_NAME_REPLACE_DASH")
+
+	config.ApplyEnv()
+	if PARALLEL {
+		t.Error("expected `parallel = false` of the file to apply")
+	}
+	if SHORTER_NAMES {
+		t.Error("expected `DYNATRACE_SHORTER_NAMES` to win over the file")
+	}
+	if !NAME_REPLACE_DASH {
+		t.Error("expected `replace_dash = true` of the file to apply")
+	}
+	if PREV_STATE_PATH_THIS != prevStatePath {
+		t.Errorf("expected `prev_state_path_this` of the file to apply, got `%s`", PREV_STATE_PATH_THIS)
+	}
+}
+
+func TestTargetFolderPrecedence(t *testing.T) {
+	targetFolder := "from-file"
+	clean := true
+	config := &ExportConfig{TargetFolder: &targetFolder, CleanTargetFolder: &clean}
+
+	unsetenv(t, "DYNATRACE_TARGET_FOLDER")
+	unsetenv(t, "DYNATRACE_CLEAN_TARGET_FOLDER")
+	if folder := config.GetTargetFolder(); folder != "from-file" {
+		t.Errorf("expected the target folder of the file, got `%s`", folder)
+	}
+	if !config.IsCleanTargetFolder() {
+		t.Error("expected `clean_target_folder` of the file to apply")
+	}
+	var noConfig *ExportConfig
+	if folder := noConfig.GetTargetFolder(); folder != "" {
+		t.Errorf("expected no target folder without configuration, got `%s`", folder)
+	}
+
+	t.Setenv("DYNATRACE_TARGET_FOLDER", "from-env")
+	t.Setenv("DYNATRACE_CLEAN_TARGET_FOLDER", "false")
+	if folder := config.GetTargetFolder(); folder != "from-env" {
+		t.Errorf("expected `DYNATRACE_TARGET_FOLDER` to win over the file, got `%s`", folder)
+	}
+	if config.IsCleanTargetFolder() {
+		t.Error("expected `DYNATRACE_CLEAN_TARGET_FOLDER` to win over the file")
+	}
+}
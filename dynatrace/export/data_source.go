@@ -1,6 +1,7 @@
 package export
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -19,6 +20,35 @@ type DataSource struct {
 	UniqueName string
 }
 
+// Declaration produces the HCL code declaring this data source
+func (me *DataSource) Declaration() string {
+	if me.Type == string(DataSourceKindTenant) {
+		return `
+			data "dynatrace_tenant" "tenant" {
+			}`
+	}
+	if me.Type == string(DataSourceKindPolicy) {
+		qualifier := ""
+		dsid := me.ID
+		if strings.Contains(dsid, "#-#environment#-#") {
+			qualifier = fmt.Sprintf("environment = \"%s\"", dsid[strings.LastIndex(dsid, "#-#")+3:])
+		} else if strings.Contains(dsid, "#-#account#-#") {
+			qualifier = fmt.Sprintf("account = \"%s\"", dsid[strings.LastIndex(dsid, "#-#")+3:])
+		}
+		return fmt.Sprintf(`			
+			data "dynatrace_iam_policy" "%s" {
+				%s
+				name = "%s"
+			}`, me.UniqueName, qualifier, me.Name)
+	}
+	dd, _ := json.Marshal(me.Name)
+	return fmt.Sprintf(`
+				data "dynatrace_entity" "%s" {
+					type = "%s"
+					name = %s
+				}`, me.ID, me.Type, string(dd))
+}
+
 func AsDataSource(resource *Resource) string {
 	if resource == nil {
 		return ""
@@ -0,0 +1,196 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export/multiuse"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const DIFF_CHECK_REPORT_FILE = "diff-check.json"
+
+// DiffCheckReport lists the exported resources `terraform plan` would want to update in-place right after importing them
+type DiffCheckReport struct {
+	Environment string                                `json:"environment"`
+	Checked     int                                   `json:"checked"`
+	Modules     map[ResourceType][]*DiffCheckResource `json:"modules"`
+}
+
+type DiffCheckResource struct {
+	ID         string            `json:"id"`
+	UniqueName string            `json:"unique_name"`
+	File       string            `json:"file,omitempty"`
+	Changes    []AttributeChange `json:"changes,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// CheckDiffs reads the exported configuration back in and plans it against the settings `Get` delivers for each resource,
+// the same way `terraform plan` would do right after an import.
+// The settings are getting served by the cache of the export, hence no additional requests are necessary.
+func (me *Environment) CheckDiffs() (*DiffCheckReport, error) {
+	hclResources, err := ReadHCLResources(me.OutputFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DiffCheckReport{Environment: me.Credentials.URL, Modules: map[ResourceType][]*DiffCheckResource{}}
+	resourceTypes := []string{}
+	for resourceType := range me.Modules {
+		resourceTypes = append(resourceTypes, string(resourceType))
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		module := me.Modules[ResourceType(resourceType)]
+		if module.Service == nil {
+			continue
+		}
+		resources := []*Resource{}
+		for _, resource := range module.Resources {
+			if resource.Status.IsOneOf(ResourceStati.Downloaded, ResourceStati.PostProcessed) {
+				resources = append(resources, resource)
+			}
+		}
+		sort.Slice(resources, func(i, j int) bool { return resources[i].UniqueName < resources[j].UniqueName })
+
+		for _, resource := range resources {
+			hclResource, found := hclResources[string(resource.Type)+"."+resource.UniqueName]
+			if !found {
+				continue
+			}
+			report.Checked++
+			check := &DiffCheckResource{ID: resource.ID, UniqueName: resource.UniqueName, File: hclResource.File}
+			sttngs := module.GetDescriptor().NewSettings()
+			if err := module.Service.Get(Context, multiuse.EncodeIDParent(resource.ID, resource.ParentID), sttngs); err != nil {
+				check.Error = err.Error()
+			} else if check.Changes, err = DiffCheck(resource.ID, sttngs, hclResource.Properties); err != nil {
+				check.Error = err.Error()
+			}
+			if len(check.Changes) > 0 || len(check.Error) > 0 {
+				report.Modules[resource.Type] = append(report.Modules[resource.Type], check)
+			}
+		}
+	}
+	return report, nil
+}
+
+// DiffCheck stores the given settings into a state the way the `Read` of a resource does it
+// and plans the given configuration against it. The result contains every attribute that would
+// get updated in-place, except for sensitive ones, which the API doesn't deliver in clear text.
+func DiffCheck(id string, sttngs settings.Settings, config hcl.Properties) (changes []AttributeChange, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("planning `%s` failed: %v", id, r)
+		}
+	}()
+
+	resourceSchema := schema.InternalMap(sttngs.Schema())
+	data, err := resourceSchema.Data(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	marshalled := hcl.Properties{}
+	if err := sttngs.MarshalHCL(marshalled); err != nil {
+		return nil, err
+	}
+	for key, value := range marshalled {
+		if err := data.Set(key, value); err != nil {
+			return nil, fmt.Errorf("unable to store `%s` in the state: %s", key, err.Error())
+		}
+	}
+	data.SetId(id)
+
+	// `terraform.NewResourceConfigRaw` only deals with plain maps, lists and primitives
+	var raw map[string]any
+	bytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return nil, err
+	}
+
+	diff, err := resourceSchema.Diff(Context, data.State(), terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	changes = []AttributeChange{}
+	if diff == nil {
+		return changes, nil
+	}
+	for attrPath, attrDiff := range diff.Attributes {
+		if attrDiff.NewComputed || attrDiff.Sensitive || attrDiff.Old == attrDiff.New {
+			continue
+		}
+		changes = append(changes, AttributeChange{Path: attrPath, Old: attrDiff.Old, New: attrDiff.New})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// WriteDiffCheckReport prints the resources which would produce a non-empty plan after being imported
+// and stores the details in `diff-check.json` within the output folder
+func (me *Environment) WriteDiffCheckReport() error {
+	if !me.Flags.DiffCheck {
+		return nil
+	}
+	fmt.Println("Checking for perpetual diffs")
+	report, err := me.CheckDiffs()
+	if err != nil {
+		return err
+	}
+	resourceTypes := []string{}
+	for resourceType := range report.Modules {
+		resourceTypes = append(resourceTypes, string(resourceType))
+	}
+	sort.Strings(resourceTypes)
+	affected, failed := 0, 0
+	for _, resourceType := range resourceTypes {
+		for _, check := range report.Modules[ResourceType(resourceType)] {
+			if len(check.Error) > 0 {
+				failed++
+				fmt.Printf("%s.%s: %s\n", resourceType, check.UniqueName, check.Error)
+				continue
+			}
+			affected++
+			fmt.Printf("%s.%s\n", resourceType, check.UniqueName)
+			for _, change := range check.Changes {
+				fmt.Printf("  %s: %q => %q\n", change.Path, change.Old, change.New)
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path.Join(me.OutputFolder, DIFF_CHECK_REPORT_FILE), data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Diff check: %d resources checked, %d would get updated in-place, %d failed. Details in %s\n", report.Checked, affected, failed, path.Join(me.OutputFolder, DIFF_CHECK_REPORT_FILE))
+	return nil
+}
@@ -0,0 +1,109 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package export_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// diffCheckSettings is shaped after the settings `Get` delivers, including a default, a set and a secret
+type diffCheckSettings struct {
+	Name     string
+	Port     int
+	Tags     []string
+	Password string
+}
+
+func (me *diffCheckSettings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name":     {Type: schema.TypeString, Required: true},
+		"port":     {Type: schema.TypeInt, Optional: true, Default: 80},
+		"tags":     {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+	}
+}
+
+func (me *diffCheckSettings) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"name":     me.Name,
+		"port":     me.Port,
+		"tags":     me.Tags,
+		"password": me.Password,
+	})
+}
+
+func (me *diffCheckSettings) UnmarshalHCL(decoder hcl.Decoder) error {
+	return decoder.DecodeAll(map[string]any{
+		"name":     &me.Name,
+		"port":     &me.Port,
+		"tags":     &me.Tags,
+		"password": &me.Password,
+	})
+}
+
+func TestDiffCheck(t *testing.T) {
+	remote := func() *diffCheckSettings {
+		return &diffCheckSettings{Name: "checkout", Port: 8080, Tags: []string{"a", "b", "c"}, Password: "secret"}
+	}
+	tests := []struct {
+		name     string
+		config   hcl.Properties
+		expected []export.AttributeChange
+	}{
+		{
+			name:     "no diff",
+			config:   hcl.Properties{"name": "checkout", "port": 8080, "tags": []any{"a", "b", "c"}, "password": "secret"},
+			expected: []export.AttributeChange{},
+		},
+		{
+			name:     "changed attribute",
+			config:   hcl.Properties{"name": "payment", "port": 8080, "tags": []any{"a", "b", "c"}, "password": "secret"},
+			expected: []export.AttributeChange{{Path: "name", Old: "checkout", New: "payment"}},
+		},
+		{
+			name:     "default induced diff",
+			config:   hcl.Properties{"name": "checkout", "tags": []any{"a", "b", "c"}, "password": "secret"},
+			expected: []export.AttributeChange{{Path: "port", Old: "8080", New: "80"}},
+		},
+		{
+			name:     "reordered set",
+			config:   hcl.Properties{"name": "checkout", "port": 8080, "tags": []any{"c", "a", "b"}, "password": "secret"},
+			expected: []export.AttributeChange{},
+		},
+		{
+			name:     "sensitive attribute",
+			config:   hcl.Properties{"name": "checkout", "port": 8080, "tags": []any{"a", "b", "c"}, "password": "${state.secret_value}"},
+			expected: []export.AttributeChange{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := export.DiffCheck("id", remote(), test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(changes, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, changes)
+			}
+		})
+	}
+}
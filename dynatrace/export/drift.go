@@ -0,0 +1,510 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hclgen"
+	hcl2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const DRIFT_REPORT_FILE = "drift-report.json"
+
+var idCommentRegex = regexp.MustCompile(`^#\s*ID\s+(.+)$`)
+var resourceHeaderRegex = regexp.MustCompile(`^resource\s+"([^"]+)"\s+"([^"]+)"`)
+
+// HCLResource is a single `resource` block read back from an export folder
+type HCLResource struct {
+	Type              ResourceType
+	UniqueName        string
+	ID                string
+	File              string
+	RequiresAttention bool
+	Flawed            bool
+	Properties        hcl.Properties
+}
+
+func (me *HCLResource) key() string {
+	return string(me.Type) + "." + me.UniqueName
+}
+
+type HCLResources map[string]*HCLResource
+
+// ResourceTypes returns the sorted list of resource types contained in these resources
+func (me HCLResources) ResourceTypes() []string {
+	m := map[string]bool{}
+	for _, res := range me {
+		m[string(res.Type)] = true
+	}
+	result := []string{}
+	for resourceType := range m {
+		result = append(result, resourceType)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ReadHCLResources parses every `.tf` file within the given export folder,
+// including `.requires_attention` and `.flawed`, and returns the `resource` blocks
+// found, keyed by `<resource type>.<unique name>`
+func ReadHCLResources(folder string) (HCLResources, error) {
+	if _, err := os.Stat(folder); err != nil {
+		return nil, err
+	}
+	resources := HCLResources{}
+	err := filepath.Walk(folder, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".terraform" || info.Name() == INCREMENTAL_FOLDER {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(info.Name(), ".tf") && !strings.HasSuffix(info.Name(), TF_JSON_EXTENSION) {
+			return nil
+		}
+		relPath, _ := filepath.Rel(folder, filePath)
+		relPath = filepath.ToSlash(relPath)
+		requiresAttention := strings.HasPrefix(relPath, ".requires_attention/")
+		flawed := strings.HasPrefix(relPath, ".flawed/")
+
+		fileResources, err := readHCLFile(filePath)
+		if err != nil {
+			return err
+		}
+		for _, fileResource := range fileResources {
+			fileResource.File = relPath
+			stored, found := resources[fileResource.key()]
+			if !found {
+				stored = fileResource
+				resources[fileResource.key()] = stored
+			}
+			// files within `.requires_attention` and `.flawed` are hard links
+			// of the files within the modules - they are only used for flagging
+			if requiresAttention || flawed {
+				if found {
+					stored.RequiresAttention = stored.RequiresAttention || requiresAttention
+					stored.Flawed = stored.Flawed || flawed
+				} else {
+					stored.RequiresAttention = requiresAttention
+					stored.Flawed = flawed
+				}
+			} else if found {
+				stored.File = relPath
+				if len(stored.ID) == 0 {
+					stored.ID = fileResource.ID
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func readHCLFile(filePath string) ([]*HCLResource, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(filePath, TF_JSON_EXTENSION) {
+		return readJSONFile(filePath, data)
+	}
+	file, diags := hclsyntax.ParseConfig(data, filePath, hcl2.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse `%s`: %s", filePath, diags.Error())
+	}
+	ids := readIDComments(data)
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil
+	}
+	resources := []*HCLResource{}
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		resources = append(resources, &HCLResource{
+			Type:       ResourceType(block.Labels[0]),
+			UniqueName: block.Labels[1],
+			ID:         ids[block.Labels[0]+"."+block.Labels[1]],
+			Properties: bodyToProperties(block.Body, data),
+		})
+	}
+	return resources, nil
+}
+
+// readJSONFile reads the `resource` blocks of a file written in Terraform JSON syntax (flag `-json`).
+// Comments, and therefore IDs, are stored within the property `//` of a resource
+func readJSONFile(filePath string, data []byte) ([]*HCLResource, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("unable to parse `%s`: %s", filePath, err.Error())
+	}
+	types, ok := root["resource"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	resources := []*HCLResource{}
+	for resourceType, names := range types {
+		names, ok := names.(map[string]any)
+		if !ok {
+			continue
+		}
+		for uniqueName, body := range names {
+			body, ok := body.(map[string]any)
+			if !ok {
+				continue
+			}
+			id := ""
+			if comment, ok := body[hclgen.JSONCommentKey].(string); ok {
+				for _, line := range strings.Split(comment, "\n") {
+					if match := idCommentRegex.FindStringSubmatch("# " + strings.TrimSpace(line)); match != nil {
+						id = strings.TrimSpace(match[1])
+					}
+				}
+			}
+			resources = append(resources, &HCLResource{
+				Type:       ResourceType(resourceType),
+				UniqueName: uniqueName,
+				ID:         id,
+				Properties: jsonToProperties(body),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// jsonToProperties mirrors `bodyToProperties` for Terraform JSON syntax.
+// Nested blocks are represented as arrays of objects
+func jsonToProperties(body map[string]any) hcl.Properties {
+	properties := hcl.Properties{}
+	for name, value := range body {
+		if name == hclgen.JSONCommentKey {
+			continue
+		}
+		if elems, ok := value.([]any); ok && len(elems) > 0 {
+			blocks := []any{}
+			for _, elem := range elems {
+				if elemBody, ok := elem.(map[string]any); ok {
+					blocks = append(blocks, jsonToProperties(elemBody))
+				}
+			}
+			if len(blocks) == len(elems) {
+				properties[name] = blocks
+				continue
+			}
+		}
+		properties[name] = value
+	}
+	return properties
+}
+
+// readIDComments extracts the IDs the export has written as `# ID ...` comments (flag `-id`)
+func readIDComments(data []byte) map[string]string {
+	ids := map[string]string{}
+	lastID := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if match := idCommentRegex.FindStringSubmatch(line); match != nil {
+			lastID = strings.TrimSpace(match[1])
+			continue
+		}
+		if match := resourceHeaderRegex.FindStringSubmatch(line); match != nil {
+			if len(lastID) > 0 {
+				ids[match[1]+"."+match[2]] = lastID
+			}
+			lastID = ""
+		}
+	}
+	return ids
+}
+
+func bodyToProperties(body *hclsyntax.Body, data []byte) hcl.Properties {
+	properties := hcl.Properties{}
+	for name, attr := range body.Attributes {
+		properties[name] = exprToValue(attr.Expr, data)
+	}
+	for _, block := range body.Blocks {
+		var blocks []any
+		if stored, found := properties[block.Type]; found {
+			blocks, _ = stored.([]any)
+		}
+		properties[block.Type] = append(blocks, bodyToProperties(block.Body, data))
+	}
+	return properties
+}
+
+// exprToValue evaluates literal expressions. Expressions which can't get evaluated
+// without context (references, function calls) are kept as their source text
+func exprToValue(expr hclsyntax.Expression, data []byte) any {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return strings.TrimSpace(string(expr.Range().SliceBytes(data)))
+	}
+	if value.IsNull() {
+		return nil
+	}
+	simple := ctyjson.SimpleJSONValue{Value: value}
+	bytes, err := simple.MarshalJSON()
+	if err != nil {
+		return strings.TrimSpace(string(expr.Range().SliceBytes(data)))
+	}
+	var result any
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return strings.TrimSpace(string(expr.Range().SliceBytes(data)))
+	}
+	return result
+}
+
+// flattenProperties produces a map of attribute paths (e.g. `rules[0].name`) to primitive values
+func flattenProperties(prefix string, v any, flattened map[string]any) {
+	switch typed := v.(type) {
+	case hcl.Properties:
+		flattenProperties(prefix, map[string]any(typed), flattened)
+	case map[string]any:
+		for key, value := range typed {
+			if len(prefix) == 0 {
+				flattenProperties(key, value, flattened)
+			} else {
+				flattenProperties(prefix+"."+key, value, flattened)
+			}
+		}
+	case []any:
+		nested := false
+		for _, elem := range typed {
+			switch elem.(type) {
+			case hcl.Properties, map[string]any:
+				nested = true
+			}
+		}
+		if !nested {
+			flattened[prefix] = typed
+			return
+		}
+		for idx, elem := range typed {
+			flattenProperties(fmt.Sprintf("%s[%d]", prefix, idx), elem, flattened)
+		}
+	default:
+		flattened[prefix] = typed
+	}
+}
+
+// AttributeChange describes a single attribute that differs between two exports
+type AttributeChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// DiffProperties compares two sets of properties attribute by attribute
+func DiffProperties(oldProperties hcl.Properties, newProperties hcl.Properties) []AttributeChange {
+	oldFlat := map[string]any{}
+	newFlat := map[string]any{}
+	flattenProperties("", oldProperties, oldFlat)
+	flattenProperties("", newProperties, newFlat)
+
+	attrPaths := map[string]bool{}
+	for attrPath := range oldFlat {
+		attrPaths[attrPath] = true
+	}
+	for attrPath := range newFlat {
+		attrPaths[attrPath] = true
+	}
+	sortedPaths := []string{}
+	for attrPath := range attrPaths {
+		sortedPaths = append(sortedPaths, attrPath)
+	}
+	sort.Strings(sortedPaths)
+
+	changes := []AttributeChange{}
+	for _, attrPath := range sortedPaths {
+		oldValue, oldFound := oldFlat[attrPath]
+		newValue, newFound := newFlat[attrPath]
+		if oldFound && newFound && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, AttributeChange{Path: attrPath, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+type DriftResource struct {
+	ID                string            `json:"id,omitempty"`
+	UniqueName        string            `json:"unique_name"`
+	File              string            `json:"file,omitempty"`
+	RequiresAttention bool              `json:"requires_attention,omitempty"`
+	Flawed            bool              `json:"flawed,omitempty"`
+	Changes           []AttributeChange `json:"changes,omitempty"`
+}
+
+type ModuleDrift struct {
+	Added    []DriftResource `json:"added"`
+	Removed  []DriftResource `json:"removed"`
+	Modified []DriftResource `json:"modified"`
+}
+
+func (me *ModuleDrift) IsEmpty() bool {
+	return len(me.Added) == 0 && len(me.Removed) == 0 && len(me.Modified) == 0
+}
+
+type DriftReport struct {
+	Environment    string                        `json:"environment"`
+	PreviousFolder string                        `json:"previous_folder"`
+	Modules        map[ResourceType]*ModuleDrift `json:"modules"`
+}
+
+func newDriftResource(res *HCLResource) DriftResource {
+	return DriftResource{ID: res.ID, UniqueName: res.UniqueName, File: res.File, RequiresAttention: res.RequiresAttention, Flawed: res.Flawed}
+}
+
+// CreateDriftReport compares the resources of the previous export within `me.Flags.Drift`
+// against the resources that just got downloaded into the output folder.
+// Both sides are read back from the written HCL, in order to compare like with like.
+func (me *Environment) CreateDriftReport() (*DriftReport, error) {
+	prevResources, err := ReadHCLResources(me.Flags.Drift)
+	if err != nil {
+		return nil, err
+	}
+	currResources, err := ReadHCLResources(me.OutputFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	// the HCL only contains IDs if `-id` was specified - the modules always know them
+	for _, module := range me.Modules {
+		for _, resource := range module.Resources {
+			if currResource, found := currResources[string(resource.Type)+"."+resource.UniqueName]; found && len(currResource.ID) == 0 {
+				currResource.ID = resource.ID
+			}
+		}
+	}
+
+	report := &DriftReport{Environment: me.Credentials.URL, PreviousFolder: me.Flags.Drift, Modules: map[ResourceType]*ModuleDrift{}}
+	moduleDrift := func(resourceType ResourceType) *ModuleDrift {
+		if stored, found := report.Modules[resourceType]; found {
+			return stored
+		}
+		drift := &ModuleDrift{Added: []DriftResource{}, Removed: []DriftResource{}, Modified: []DriftResource{}}
+		report.Modules[resourceType] = drift
+		return drift
+	}
+
+	prevByID := map[string]*HCLResource{}
+	for _, prevResource := range prevResources {
+		if len(prevResource.ID) > 0 {
+			prevByID[string(prevResource.Type)+"."+prevResource.ID] = prevResource
+		}
+	}
+
+	matched := map[string]bool{}
+	for _, key := range sortedKeys(currResources) {
+		currResource := currResources[key]
+		var prevResource *HCLResource
+		if len(currResource.ID) > 0 {
+			prevResource = prevByID[string(currResource.Type)+"."+currResource.ID]
+		}
+		if prevResource == nil {
+			prevResource = prevResources[key]
+		}
+		if prevResource == nil {
+			drift := moduleDrift(currResource.Type)
+			drift.Added = append(drift.Added, newDriftResource(currResource))
+			continue
+		}
+		matched[prevResource.key()] = true
+		if changes := DiffProperties(prevResource.Properties, currResource.Properties); len(changes) > 0 {
+			driftResource := newDriftResource(currResource)
+			driftResource.Changes = changes
+			drift := moduleDrift(currResource.Type)
+			drift.Modified = append(drift.Modified, driftResource)
+		}
+	}
+
+	for _, key := range sortedKeys(prevResources) {
+		prevResource := prevResources[key]
+		if matched[key] {
+			continue
+		}
+		// resources of modules which couldn't get downloaded are not considered removed
+		if module, found := me.Modules[prevResource.Type]; !found || module.Status == ModuleStati.Erronous {
+			continue
+		}
+		drift := moduleDrift(prevResource.Type)
+		drift.Removed = append(drift.Removed, newDriftResource(prevResource))
+	}
+
+	for resourceType, drift := range report.Modules {
+		if drift.IsEmpty() {
+			delete(report.Modules, resourceType)
+		}
+	}
+	return report, nil
+}
+
+// WriteDriftReport writes the result of `CreateDriftReport` as JSON into the output folder
+func (me *Environment) WriteDriftReport() error {
+	if len(me.Flags.Drift) == 0 {
+		return nil
+	}
+	fmt.Println("Writing " + DRIFT_REPORT_FILE)
+	report, err := me.CreateDriftReport()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path.Join(me.OutputFolder, DRIFT_REPORT_FILE), data, 0644); err != nil {
+		return err
+	}
+	added, removed, modified := 0, 0, 0
+	for _, drift := range report.Modules {
+		added += len(drift.Added)
+		removed += len(drift.Removed)
+		modified += len(drift.Modified)
+	}
+	fmt.Printf("Drift: %d added, %d removed, %d modified\n", added, removed, modified)
+	return nil
+}
+
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
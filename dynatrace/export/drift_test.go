@@ -0,0 +1,114 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package export_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+)
+
+func TestDiffProperties(t *testing.T) {
+	properties := func() hcl.Properties {
+		return hcl.Properties{
+			"name":    "checkout",
+			"enabled": true,
+			"tags":    []any{"a", "b"},
+			"rules": []any{
+				hcl.Properties{"name": "first", "conditions": []any{map[string]any{"key": "k1", "value": "v1"}}},
+				map[string]any{"name": "second"},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		old      hcl.Properties
+		new      func(hcl.Properties) hcl.Properties
+		expected []export.AttributeChange
+	}{
+		{
+			name:     "unchanged",
+			old:      properties(),
+			new:      func(p hcl.Properties) hcl.Properties { return p },
+			expected: []export.AttributeChange{},
+		},
+		{
+			name: "modified attribute",
+			old:  properties(),
+			new: func(p hcl.Properties) hcl.Properties {
+				p["enabled"] = false
+				return p
+			},
+			expected: []export.AttributeChange{{Path: "enabled", Old: true, New: false}},
+		},
+		{
+			name: "modified nested attribute",
+			old:  properties(),
+			new: func(p hcl.Properties) hcl.Properties {
+				p["rules"].([]any)[0].(hcl.Properties)["conditions"] = []any{map[string]any{"key": "k1", "value": "v2"}}
+				return p
+			},
+			expected: []export.AttributeChange{{Path: "rules[0].conditions[0].value", Old: "v1", New: "v2"}},
+		},
+		{
+			name: "primitive lists are compared as a whole",
+			old:  properties(),
+			new: func(p hcl.Properties) hcl.Properties {
+				p["tags"] = []any{"a", "b", "c"}
+				return p
+			},
+			expected: []export.AttributeChange{{Path: "tags", Old: []any{"a", "b"}, New: []any{"a", "b", "c"}}},
+		},
+		{
+			name: "added and removed attributes",
+			old:  properties(),
+			new: func(p hcl.Properties) hcl.Properties {
+				delete(p, "name")
+				p["rules"] = append(p["rules"].([]any), hcl.Properties{"name": "third"})
+				return p
+			},
+			expected: []export.AttributeChange{
+				{Path: "name", Old: "checkout"},
+				{Path: "rules[2].name", New: "third"},
+			},
+		},
+		{
+			name: "flattened paths",
+			old:  hcl.Properties{},
+			new:  func(p hcl.Properties) hcl.Properties { return p },
+			expected: []export.AttributeChange{
+				{Path: "enabled", New: true},
+				{Path: "name", New: "checkout"},
+				{Path: "rules[0].conditions[0].key", New: "k1"},
+				{Path: "rules[0].conditions[0].value", New: "v1"},
+				{Path: "rules[0].name", New: "first"},
+				{Path: "rules[1].name", New: "second"},
+				{Path: "tags", New: []any{"a", "b"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := export.DiffProperties(test.old, test.new(properties()))
+			if !reflect.DeepEqual(changes, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, changes)
+			}
+		})
+	}
+}
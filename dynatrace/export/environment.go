@@ -48,6 +48,7 @@ var NO_REFRESH_ON_IMPORT = os.Getenv("DYNATRACE_NO_REFRESH_ON_IMPORT") == "true"
 var QUICK_INIT = os.Getenv("DYNATRACE_QUICK_INIT") == "true"
 var ULTRA_PARALLEL = os.Getenv("DYNATRACE_ULTRA_PARALLEL") == "true"
 var JSON_DASHBOARD_BASE_PLUS = os.Getenv("DYNATRACE_JSON_DASHBOARD_BASE_PLUS") == "true"
+var PARALLEL = os.Getenv("DYNATRACE_PARALLEL") != "false"
 
 const ENV_VAR_CUSTOM_PROVIDER_LOCATION = "DYNATRACE_CUSTOM_PROVIDER_LOCATION"
 
@@ -65,6 +66,11 @@ type Environment struct {
 	ChildParentGroups     map[ResourceType]ResourceType
 	IsParentMap           map[ResourceType]bool
 	HasDependenciesTo     map[ResourceType]bool
+	Filters               map[ResourceType][]*ResourceFilter
+	Layout                Layout
+	layoutGroups          map[string]*layoutGroup
+	// with `-incremental` the OutputFolder is a staging folder and this is where the results end up
+	IncrementalTargetFolder string
 }
 
 func (me *Environment) TenantID() string {
@@ -242,7 +248,7 @@ func (me *Environment) ProcessPrevState() error {
 }
 
 func (me *Environment) InitialDownload() error {
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	resourceTypes := []string{}
 	for resourceType := range me.ResArgs {
 		resourceTypes = append(resourceTypes, string(resourceType))
@@ -323,7 +329,7 @@ func (me *Environment) InitialDownload() error {
 
 func (me *Environment) PostProcess() error {
 	fmt.Println("Post-Processing Resources ...")
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	resources := me.GetNonPostProcessedResources()
 
 	if parallel {
@@ -549,6 +555,10 @@ func (me *Environment) Finish() (err error) {
 		return nil
 	}
 
+	if err = me.ApplyLayout(); err != nil {
+		return err
+	}
+
 	if err = me.WriteResourceFiles(); err != nil {
 		return err
 	}
@@ -567,6 +577,9 @@ func (me *Environment) Finish() (err error) {
 	if err = me.RemoveNonReferencedModules(); err != nil {
 		return err
 	}
+	if err = me.ConvertToJSON(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -661,6 +674,9 @@ func (me *Environment) GetChildResources(optSort ...bool) []*Resource {
 }
 
 func (me *Environment) WriteDataSourceFiles() (err error) {
+	if me.IsGroupedLayout() {
+		return me.writeLayoutDataSourceFiles()
+	}
 	fmt.Println("Writing ___datasources___.tf")
 
 	if me.Flags.Flat {
@@ -710,7 +726,7 @@ func (me *Environment) WriteDataSourceFiles() (err error) {
 
 		return nil
 	}
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	if parallel {
 		var wg sync.WaitGroup
 		wg.Add(len(me.Modules))
@@ -741,8 +757,11 @@ func (me *Environment) WriteResourceFiles() (err error) {
 	if me.Flags.Flat {
 		return nil
 	}
+	if me.IsGroupedLayout() {
+		return me.writeLayoutResourceFiles()
+	}
 	fmt.Println("Writing ___resources___.tf")
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	if parallel {
 		var wg sync.WaitGroup
 		wg.Add(len(me.Modules))
@@ -807,9 +826,12 @@ func (me *Environment) WriteProviderFiles() (err error) {
 	if me.Flags.Flat {
 		return nil
 	}
+	if me.IsGroupedLayout() {
+		return me.writeLayoutProviderFiles()
+	}
 
 	fmt.Println("Writing modules ___providers___.tf")
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	if parallel {
 		var wg sync.WaitGroup
 		wg.Add(len(me.Modules))
@@ -875,8 +897,11 @@ func (me *Environment) WriteMainProviderFile() error {
 }
 
 func (me *Environment) WriteVariablesFiles() (err error) {
+	if me.IsGroupedLayout() {
+		return me.writeLayoutVariablesFiles()
+	}
 	fmt.Println("Writing ___variables___.tf")
-	parallel := (os.Getenv("DYNATRACE_PARALLEL") != "false")
+	parallel := PARALLEL
 	if parallel {
 		var wg sync.WaitGroup
 
@@ -925,6 +950,9 @@ func (me *Environment) WriteMainFile() error {
 	if me.Flags.Flat {
 		return nil
 	}
+	if me.IsGroupedLayout() {
+		return me.writeLayoutMainFile()
+	}
 	fmt.Println("Writing main.tf")
 
 	var err error
@@ -1171,6 +1199,14 @@ func (me *Environment) FinishExport() error {
 	address.SaveOriginalMap(me.OutputFolder)
 	address.SaveCompletedMap(me.OutputFolder)
 
+	if err := me.WriteManifest(); err != nil {
+		return err
+	}
+
+	if err := me.FinishIncremental(); err != nil {
+		return err
+	}
+
 	if QUICK_INIT {
 		err := me.WriteQuickModulesJSON()
 		if err != nil {
@@ -0,0 +1,257 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+var filterExpressionRegex = regexp.MustCompile(`^([A-Za-z0-9_.]+)(==|!=|!~|~)(.*)$`)
+
+// Properties which aren't named the same way in every resource type
+var filterPropertyAliases = map[string][]string{
+	"owner": {"owner", "dashboardMetadata.owner"},
+}
+
+// ResourceFilter restricts the resources of a specific type that are getting exported.
+// It is specified as `<resource_type>:<property><operator><value>`, e.g. `dynatrace_management_zone_v2:name~^team-a-`
+//
+// Supported operators are `==`, `!=`, `~` (regular expression) and `!~` (negated regular expression).
+// The properties `id`, `name` and `legacy_id` are evaluated without downloading the resource.
+// `scope` and any other property (a path into the JSON representation of the resource, e.g. `dashboard_metadata.owner`)
+// require the resource to get fetched, unless the list of the resource type already delivered it.
+// Multiple filters for the same resource type need to match all.
+type ResourceFilter struct {
+	ResourceType ResourceType
+	Property     string
+	Operator     string
+	Value        string
+	regex        *regexp.Regexp
+}
+
+// IsResourceFilter checks whether a command line argument is a filter expression rather than `resource_type` or `resource_type=id`
+func IsResourceFilter(arg string) bool {
+	idx := strings.Index(arg, ":")
+	if idx < 0 {
+		return false
+	}
+	key, _ := ValidateResource(arg[:idx])
+	return len(key) > 0 && !strings.Contains(arg[:idx], "=")
+}
+
+func ParseResourceFilter(arg string) (*ResourceFilter, error) {
+	idx := strings.Index(arg, ":")
+	if idx < 0 {
+		return nil, fmt.Errorf("invalid filter `%s`: expected `<resource_type>:<property><operator><value>`", arg)
+	}
+	key, _ := ValidateResource(arg[:idx])
+	if len(key) == 0 {
+		return nil, fmt.Errorf("unknown resource `%s`", arg[:idx])
+	}
+	match := filterExpressionRegex.FindStringSubmatch(arg[idx+1:])
+	if match == nil {
+		return nil, fmt.Errorf("invalid filter `%s`: expected `<resource_type>:<property><operator><value>` with one of the operators `==`, `!=`, `~`, `!~`", arg)
+	}
+	filter := &ResourceFilter{ResourceType: ResourceType(key), Property: match[1], Operator: match[2], Value: match[3]}
+	if filter.Operator == "~" || filter.Operator == "!~" {
+		var err error
+		if filter.regex, err = regexp.Compile(filter.Value); err != nil {
+			return nil, fmt.Errorf("invalid filter `%s`: %s", arg, err.Error())
+		}
+	}
+	return filter, nil
+}
+
+func (me *ResourceFilter) String() string {
+	return fmt.Sprintf("%s:%s%s%s", me.ResourceType, me.Property, me.Operator, me.Value)
+}
+
+// Matches evaluates the filter against a stub. For properties not contained in the stub `value` gets invoked to get hold of the settings.
+func (me *ResourceFilter) Matches(stub *api.Stub, value func() (settings.Settings, error)) (bool, error) {
+	var candidates []string
+	switch me.Property {
+	case "id":
+		candidates = []string{stub.ID}
+	case "name":
+		candidates = []string{stub.Name}
+	case "legacy_id":
+		if stub.LegacyID != nil {
+			candidates = []string{*stub.LegacyID}
+		}
+	default:
+		v, err := value()
+		if err != nil {
+			return false, err
+		}
+		if v == nil {
+			break
+		}
+		if me.Property == "scope" {
+			candidates = []string{settings.GetScope(v)}
+			break
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return false, err
+		}
+		var m any
+		if err := json.Unmarshal(data, &m); err != nil {
+			return false, err
+		}
+		paths := filterPropertyAliases[me.Property]
+		if len(paths) == 0 {
+			paths = []string{me.Property}
+		}
+		for _, propertyPath := range paths {
+			candidates = append(candidates, lookupFilterProperty(m, strings.Split(propertyPath, "."))...)
+		}
+	}
+	negated := me.Operator == "!=" || me.Operator == "!~"
+	for _, candidate := range candidates {
+		if me.matches(candidate) {
+			return !negated, nil
+		}
+	}
+	return negated, nil
+}
+
+func (me *ResourceFilter) matches(candidate string) bool {
+	if me.regex != nil {
+		return me.regex.MatchString(candidate)
+	}
+	return candidate == me.Value
+}
+
+// lookupFilterProperty resolves a property path within unmarshalled JSON.
+// Path elements are accepted in snake case (as in HCL) or camel case (as in JSON).
+// Lists are getting searched element by element.
+func lookupFilterProperty(v any, path []string) []string {
+	switch typed := v.(type) {
+	case []any:
+		result := []string{}
+		for _, elem := range typed {
+			result = append(result, lookupFilterProperty(elem, path)...)
+		}
+		return result
+	case map[string]any:
+		if len(path) == 0 {
+			return nil
+		}
+		elem, found := typed[path[0]]
+		if !found {
+			elem, found = typed[toCamelCase(path[0])]
+		}
+		if !found {
+			return nil
+		}
+		return lookupFilterProperty(elem, path[1:])
+	case nil:
+		return nil
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("%v", typed)}
+	}
+}
+
+func toCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for idx := 1; idx < len(parts); idx++ {
+		if len(parts[idx]) > 0 {
+			parts[idx] = strings.ToUpper(parts[idx][:1]) + parts[idx][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// filter evaluates the filters configured for this module against the discovered stubs
+// and remembers the IDs of the resources that should get exported
+func (me *Module) filter(stubs api.Stubs) error {
+	filters := me.Environment.Filters[me.Type]
+	if len(filters) == 0 {
+		return nil
+	}
+	me.FilteredIDs = map[string]bool{}
+	for _, stub := range stubs {
+		var loaded settings.Settings
+		value := func() (settings.Settings, error) {
+			if loaded != nil {
+				return loaded, nil
+			}
+			if stub.Value != nil {
+				if v, ok := stub.Value.(settings.Settings); ok {
+					loaded = v
+					return loaded, nil
+				}
+			}
+			v := me.GetDescriptor().NewSettings()
+			if err := me.Service.Get(Context, stub.ID, v); err != nil {
+				return nil, err
+			}
+			loaded = v
+			return loaded, nil
+		}
+		matches := true
+		for _, filter := range filters {
+			matched, err := filter.Matches(stub, value)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			me.FilteredIDs[stub.ID] = true
+		}
+	}
+	return nil
+}
+
+// isFilteredOut checks whether the resource with the given ID doesn't pass the filters of this module.
+// Child resources follow their parent.
+func (me *Module) isFilteredOut(id string, parentID *string) (bool, error) {
+	if parentType := me.GetDescriptor().Parent; parentType != nil && !me.Environment.ChildResourceOverride {
+		parent := me.Environment.Module(*parentType)
+		if len(me.Environment.Filters[parent.Type]) > 0 {
+			if err := parent.Discover(); err != nil {
+				return false, err
+			}
+			if parent.FilteredIDs != nil {
+				if parentID == nil {
+					parentID = &id
+				}
+				if !parent.FilteredIDs[*parentID] {
+					return true, nil
+				}
+			}
+		}
+	}
+	if me.FilteredIDs == nil {
+		return false, nil
+	}
+	return !me.FilteredIDs[id], nil
+}
@@ -0,0 +1,134 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// filterService lists the given stubs and records the IDs of the resources getting downloaded
+type filterService struct {
+	stubs      api.Stubs
+	mu         sync.Mutex
+	downloaded []string
+}
+
+func (me *filterService) List(ctx context.Context) (api.Stubs, error) {
+	return me.stubs, nil
+}
+
+func (me *filterService) Get(ctx context.Context, id string, v settings.Settings) error {
+	me.mu.Lock()
+	me.downloaded = append(me.downloaded, id)
+	me.mu.Unlock()
+	// the download is of no interest - only whether it has been attempted
+	return rest.Error{Code: 404, Message: "not found"}
+}
+
+func (me *filterService) Create(ctx context.Context, v settings.Settings) (*api.Stub, error) {
+	return nil, nil
+}
+
+func (me *filterService) Update(ctx context.Context, id string, v settings.Settings) error {
+	return nil
+}
+
+func (me *filterService) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (me *filterService) Validate(v settings.Settings) error {
+	return nil
+}
+
+func (me *filterService) SchemaID() string {
+	return "fake:filter"
+}
+
+func (me *filterService) Downloaded() []string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	result := append([]string{}, me.downloaded...)
+	sort.Strings(result)
+	return result
+}
+
+func TestFiltersRestrictDownload(t *testing.T) {
+	filter, err := export.ParseResourceFilter("dynatrace_json_dashboard_base:name~^team-a-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	environment := &export.Environment{
+		OutputFolder: t.TempDir(),
+		Credentials:  &settings.Credentials{},
+		Modules:      map[export.ResourceType]*export.Module{},
+		ResArgs:      map[string][]string{},
+		Filters:      map[export.ResourceType][]*export.ResourceFilter{filter.ResourceType: {filter}},
+	}
+
+	parents := &filterService{stubs: api.Stubs{
+		{ID: "dashboard-1", Name: "team-a-overview"},
+		{ID: "dashboard-2", Name: "team-b-overview"},
+		{ID: "dashboard-3", Name: "team-a-details"},
+	}}
+	children := &filterService{stubs: api.Stubs{
+		{ID: "dashboard-1", Name: "team-a-overview"},
+		{ID: "dashboard-2", Name: "team-b-overview"},
+		{ID: "dashboard-3", Name: "team-a-details"},
+	}}
+	environment.Module(export.ResourceTypes.JSONDashboardBase).Service = parents
+	environment.Module(export.ResourceTypes.JSONDashboard).Service = children
+
+	for _, resourceType := range []export.ResourceType{export.ResourceTypes.JSONDashboardBase, export.ResourceTypes.JSONDashboard} {
+		if err := environment.Module(resourceType).Download(false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{"dashboard-1", "dashboard-3"}
+	if downloaded := parents.Downloaded(); !equalStrings(downloaded, expected) {
+		t.Errorf("expected %v to get downloaded, actual: %v", expected, downloaded)
+	}
+	// child resources follow the filters of their parent
+	if downloaded := children.Downloaded(); !equalStrings(downloaded, expected) {
+		t.Errorf("expected child resources %v to get downloaded, actual: %v", expected, downloaded)
+	}
+	if _, found := environment.Module(export.ResourceTypes.JSONDashboard).Resources["dashboard-2"]; found {
+		t.Error("expected the child resource of a filtered out parent not to get registered")
+	}
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
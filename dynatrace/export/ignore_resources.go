@@ -73,7 +73,7 @@ func LoadIgnoreResourcesMap() error {
 			}
 		}
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 
 	return nil
 }
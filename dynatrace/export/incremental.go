@@ -0,0 +1,286 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// INCREMENTAL_FOLDER is the folder within the target folder which holds the state of `-incremental` exports.
+// It contains the settings objects fetched by previous runs (`cache`) and the output of the current run (`staging`).
+const INCREMENTAL_FOLDER = ".incremental"
+
+func incrementalCacheFolder(targetFolder string) string {
+	return path.Join(targetFolder, INCREMENTAL_FOLDER, "cache")
+}
+
+func incrementalStagingFolder(targetFolder string) string {
+	return path.Join(targetFolder, INCREMENTAL_FOLDER, "staging")
+}
+
+// INCREMENTAL_FILES_FILE lists the files generated by previous `-incremental` exports along with the resources they contain.
+// Only files listed there are candidates for getting removed - files not written by the export are never getting touched
+const INCREMENTAL_FILES_FILE = "files.json"
+
+type generatedResource struct {
+	Type ResourceType `json:"type"`
+	ID   string       `json:"id"`
+}
+
+// generatedFiles maps the paths of generated files (relative to the target folder) to the resources they contain.
+// Files without resources are shared by the resources of a folder, like provider configurations or variables
+type generatedFiles map[string][]generatedResource
+
+func incrementalFilesFile(targetFolder string) string {
+	return path.Join(targetFolder, INCREMENTAL_FOLDER, INCREMENTAL_FILES_FILE)
+}
+
+func loadGeneratedFiles(targetFolder string) (generatedFiles, error) {
+	files := generatedFiles{}
+	data, err := os.ReadFile(incrementalFilesFile(targetFolder))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return files, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("unable to read `%s`: %s", incrementalFilesFile(targetFolder), err.Error())
+	}
+	return files, nil
+}
+
+func (me generatedFiles) save(targetFolder string) error {
+	data, err := json.MarshalIndent(me, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(path.Join(targetFolder, INCREMENTAL_FOLDER), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(incrementalFilesFile(targetFolder), data, 0644)
+}
+
+// stagedResources determines which resources the staged files contain, based on the manifest of the current run.
+// Files within `.requires_attention` and `.flawed` are copies of the files within the modules
+func (me *Environment) stagedResources(staged map[string]bool) generatedFiles {
+	byFile := map[string][]generatedResource{}
+	for _, resource := range me.CreateManifest().Resources {
+		if len(resource.File) > 0 {
+			byFile[resource.File] = append(byFile[resource.File], generatedResource{Type: resource.Type, ID: resource.ID})
+		}
+	}
+	files := generatedFiles{}
+	for relPath := range staged {
+		slashPath := filepath.ToSlash(relPath)
+		resources := byFile[slashPath]
+		for _, folder := range []string{".requires_attention/", ".flawed/"} {
+			if strings.HasPrefix(slashPath, folder) {
+				resources = byFile[strings.TrimPrefix(slashPath, folder)]
+				if resources == nil {
+					resources = byFile["modules/"+strings.TrimPrefix(slashPath, folder)]
+				}
+			}
+		}
+		files[relPath] = resources
+	}
+	return files
+}
+
+// isExportedCompletely checks whether every resource of the given type has been fetched by the current run.
+// Otherwise the absence of a resource doesn't mean that it has been removed from the environment
+func (me *Environment) isExportedCompletely(resourceType ResourceType) bool {
+	module, found := me.Modules[resourceType]
+	if !found || module.Status.IsOneOf(ModuleStati.Untouched, ModuleStati.Erronous) {
+		return false
+	}
+	if keys, found := me.ResArgs[string(resourceType)]; !found || len(keys) > 0 {
+		return false
+	}
+	if len(me.Filters[resourceType]) > 0 {
+		return false
+	}
+	if parent := module.GetDescriptor().Parent; parent != nil && len(me.Filters[*parent]) > 0 {
+		return false
+	}
+	return true
+}
+
+// isRemovable checks whether a previously generated file, which hasn't been produced by the current run,
+// contained only resources which don't exist anymore
+func (me *Environment) isRemovable(resources []generatedResource) bool {
+	if len(resources) == 0 {
+		return false
+	}
+	for _, resource := range resources {
+		if !me.isExportedCompletely(resource.Type) {
+			return false
+		}
+		// resources which failed to download still exist
+		if stored, found := me.Modules[resource.Type].Resources[resource.ID]; found && stored.Status == ResourceStati.Erronous {
+			return false
+		}
+	}
+	return true
+}
+
+// FinishIncremental transfers the files produced by an `-incremental` export from the staging folder
+// into the target folder. Files with unchanged contents remain untouched.
+// Files generated by previous runs for resources which don't exist anymore are getting removed,
+// as long as the current run has fetched every resource of their type.
+// Afterwards the environment continues to operate on the target folder.
+func (me *Environment) FinishIncremental() error {
+	if !me.Flags.Incremental {
+		return nil
+	}
+	stagingFolder := me.OutputFolder
+	targetFolder := me.IncrementalTargetFolder
+
+	written := 0
+	unchanged := 0
+	removed := 0
+
+	staged := map[string]bool{}
+	err := filepath.WalkDir(stagingFolder, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath, err := filepath.Rel(stagingFolder, filePath)
+		if err != nil {
+			return err
+		}
+		staged[relPath] = true
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		targetFile := filepath.Join(targetFolder, relPath)
+		if existing, err := os.ReadFile(targetFile); err == nil && bytes.Equal(existing, data) {
+			unchanged++
+			return nil
+		}
+		if err = os.MkdirAll(filepath.Dir(targetFile), os.ModePerm); err != nil {
+			return err
+		}
+		// hard links within `.requires_attention` or `.flawed` would otherwise get modified too
+		os.Remove(targetFile)
+		if err = os.WriteFile(targetFile, data, 0644); err != nil {
+			return err
+		}
+		written++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	prevFiles, err := loadGeneratedFiles(targetFolder)
+	if err != nil {
+		return err
+	}
+	files := me.stagedResources(staged)
+
+	removedFolders := map[string]bool{}
+	remove := func(relPath string) error {
+		if err := os.Remove(filepath.Join(targetFolder, relPath)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removedFolders[filepath.Dir(relPath)] = true
+		removed++
+		return nil
+	}
+
+	// shared files are only getting removed together with the folder they belong to
+	sharedFiles := map[string][]string{}
+	for _, relPath := range sortedKeys(prevFiles) {
+		if staged[relPath] {
+			continue
+		}
+		resources := prevFiles[relPath]
+		if len(resources) == 0 {
+			sharedFiles[filepath.Dir(relPath)] = append(sharedFiles[filepath.Dir(relPath)], relPath)
+			files[relPath] = resources
+			continue
+		}
+		if !me.isRemovable(resources) {
+			files[relPath] = resources
+			continue
+		}
+		if err = remove(relPath); err != nil {
+			return err
+		}
+	}
+	for _, folder := range sortedKeys(sharedFiles) {
+		if folder == "." || !removedFolders[folder] {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(targetFolder, folder))
+		if err != nil {
+			continue
+		}
+		// the folder may still contain resources or files not written by the export
+		onlyShared := true
+		for _, entry := range entries {
+			if entry.IsDir() || !slices.Contains(sharedFiles[folder], filepath.Join(folder, entry.Name())) {
+				onlyShared = false
+				break
+			}
+		}
+		if !onlyShared {
+			continue
+		}
+		for _, relPath := range sharedFiles[folder] {
+			if err = remove(relPath); err != nil {
+				return err
+			}
+			delete(files, relPath)
+		}
+	}
+	// folders which became empty belonged to resources which don't exist anymore
+	for _, folder := range sortedKeys(removedFolders) {
+		for ; folder != "." && folder != "/" && len(folder) > 0; folder = filepath.Dir(folder) {
+			if os.Remove(filepath.Join(targetFolder, folder)) != nil {
+				break
+			}
+		}
+	}
+	if err = files.save(targetFolder); err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(stagingFolder); err != nil {
+		return err
+	}
+	me.OutputFolder = targetFolder
+	fmt.Printf("Incremental export: %d files written, %d files unchanged, %d files removed\n", written, unchanged, removed)
+	return nil
+}
@@ -0,0 +1,129 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// incrementalRun simulates an `-incremental` export into `targetFolder`, which
+// fetched the given resources (resource type -> IDs) and nothing else
+func incrementalRun(t *testing.T, targetFolder string, fetched map[export.ResourceType][]string) {
+	t.Helper()
+	stagingFolder := filepath.Join(targetFolder, ".incremental", "staging")
+	environment := &export.Environment{
+		OutputFolder:            stagingFolder,
+		IncrementalTargetFolder: targetFolder,
+		Credentials:             &settings.Credentials{},
+		Flags:                   export.Flags{Incremental: true},
+		Modules:                 map[export.ResourceType]*export.Module{},
+		ResArgs:                 map[string][]string{},
+	}
+	writeFile(t, filepath.Join(stagingFolder, "main.tf"), "# root module")
+	for resourceType, ids := range fetched {
+		environment.ResArgs[string(resourceType)] = nil
+		module := environment.Module(resourceType)
+		module.Status = export.ModuleStati.Discovered
+		if len(ids) > 0 {
+			writeFile(t, filepath.Join(module.GetFolder(), "providers.tf"), "# providers")
+		}
+		for _, id := range ids {
+			resource := module.Resource(id)
+			resource.UniqueName = id
+			resource.Status = export.ResourceStati.PostProcessed
+			writeFile(t, resource.GetFile(), "# "+id)
+		}
+	}
+	if err := environment.FinishIncremental(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeFile(t *testing.T, file string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertExists(t *testing.T, targetFolder string, relPath string, expected bool) {
+	t.Helper()
+	_, err := os.Stat(filepath.Join(targetFolder, relPath))
+	if exists := err == nil; exists != expected {
+		if expected {
+			t.Errorf("expected `%s` to exist", relPath)
+		} else {
+			t.Errorf("expected `%s` to have been removed", relPath)
+		}
+	}
+}
+
+func TestFinishIncrementalPartialReExport(t *testing.T) {
+	targetFolder := t.TempDir()
+	incrementalRun(t, targetFolder, map[export.ResourceType][]string{
+		export.ResourceTypes.Alerting:         {"alerting-1", "alerting-2"},
+		export.ResourceTypes.ManagementZoneV2: {"zone-1"},
+	})
+	assertExists(t, targetFolder, "modules/alerting/alerting-2.alerting.tf", true)
+	assertExists(t, targetFolder, "modules/management_zone_v2/zone-1.management_zone_v2.tf", true)
+
+	// only alerting profiles are getting exported - `alerting-2` doesn't exist anymore
+	incrementalRun(t, targetFolder, map[export.ResourceType][]string{
+		export.ResourceTypes.Alerting: {"alerting-1"},
+	})
+	assertExists(t, targetFolder, "main.tf", true)
+	assertExists(t, targetFolder, "modules/alerting/providers.tf", true)
+	assertExists(t, targetFolder, "modules/alerting/alerting-1.alerting.tf", true)
+	assertExists(t, targetFolder, "modules/alerting/alerting-2.alerting.tf", false)
+	// management zones haven't been part of the export, hence they stay untouched
+	assertExists(t, targetFolder, "modules/management_zone_v2/providers.tf", true)
+	assertExists(t, targetFolder, "modules/management_zone_v2/zone-1.management_zone_v2.tf", true)
+
+	// a module without any resources left is getting removed entirely
+	incrementalRun(t, targetFolder, map[export.ResourceType][]string{
+		export.ResourceTypes.ManagementZoneV2: {},
+	})
+	assertExists(t, targetFolder, "modules/management_zone_v2", false)
+	assertExists(t, targetFolder, "modules/alerting/alerting-1.alerting.tf", true)
+}
+
+func TestFinishIncrementalKeepsForeignFiles(t *testing.T) {
+	targetFolder := t.TempDir()
+	writeFile(t, filepath.Join(targetFolder, "backend.tf"), "# backend")
+	writeFile(t, filepath.Join(targetFolder, "modules/alerting/locals.tf"), "# locals")
+
+	incrementalRun(t, targetFolder, map[export.ResourceType][]string{
+		export.ResourceTypes.Alerting: {"alerting-1"},
+	})
+	incrementalRun(t, targetFolder, map[export.ResourceType][]string{
+		export.ResourceTypes.Alerting: {},
+	})
+	assertExists(t, targetFolder, "backend.tf", true)
+	assertExists(t, targetFolder, "modules/alerting/locals.tf", true)
+	assertExists(t, targetFolder, "modules/alerting/alerting-1.alerting.tf", false)
+	// the folder still contains a file which hasn't been written by the export
+	assertExists(t, targetFolder, "modules/alerting/providers.tf", true)
+}
@@ -18,18 +18,33 @@
 package export
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache"
 )
 
 func Initialize() (environment *Environment, err error) {
 	flags, tailArgs := createFlags()
+	var config *ExportConfig
+	if len(flags.Config) > 0 {
+		if config, err = LoadExportConfig(flags.Config); err != nil {
+			return nil, err
+		}
+		config.ApplyFlags(&flags)
+		config.ApplyEnv()
+		// resources specified on the command line win over the ones in the config file
+		if len(tailArgs) == 0 {
+			tailArgs = config.TailArgs()
+		}
+	}
 	if flags.FlagMigrationOutput && flags.FollowReferences {
 		return nil, errors.New("-ref and -migrate are mutually exclusive")
 	}
@@ -37,6 +52,41 @@ func Initialize() (environment *Environment, err error) {
 		flags.FollowReferences = true
 		flags.PersistIDs = true
 	}
+	var layout Layout
+	if layout, err = NewLayout(flags.Layout); err != nil {
+		return nil, err
+	}
+	if layout.Key() == LayoutKeys.Flat {
+		flags.Flat = true
+	} else if flags.Flat && layout.Key() != LayoutKeys.ResourceType {
+		return nil, fmt.Errorf("-flat and -layout %s are mutually exclusive", layout.Key())
+	}
+	if layout.Key() == LayoutKeys.ManagementZone && !flags.FollowReferences {
+		return nil, fmt.Errorf("-layout %s requires -ref in order to detect the management zones resources are referring to", layout.Key())
+	}
+	if len(flags.Drift) > 0 {
+		// the drift report only needs the configuration files
+		flags.SkipTerraformInit = true
+		flags.ImportStateV2 = false
+	}
+	if len(flags.Record) > 0 && len(flags.Replay) > 0 {
+		return nil, errors.New("-record and -replay are mutually exclusive")
+	}
+	if len(flags.Replay) > 0 {
+		// terraform itself would reach out to the registry and the environment
+		flags.SkipTerraformInit = true
+		flags.ImportStateV2 = false
+	}
+	if flags.SchemaCheck && (len(flags.Drift) > 0 || flags.Incremental) {
+		return nil, errors.New("-schema-check cannot be combined with -drift or -incremental")
+	}
+	if flags.DiffCheck && flags.SchemaCheck {
+		return nil, errors.New("-diff-check and -schema-check are mutually exclusive")
+	}
+	if flags.DiffCheck && flags.FollowReferences {
+		// references can only get resolved by terraform itself
+		return nil, errors.New("-diff-check cannot be combined with -ref")
+	}
 	if err = ConfigureRESTLog(); err != nil {
 		return nil, errors.New("unable to configure log file for REST activity: " + err.Error())
 	}
@@ -52,6 +102,59 @@ func Initialize() (environment *Environment, err error) {
 	settings.ExportRunning = true
 	os.Setenv("dynatrace.secrets", "true")
 	cache.Enable()
+	if len(flags.Record) > 0 {
+		if err = rest.RecordHTTP(flags.Record); err != nil {
+			return nil, fmt.Errorf("unable to record HTTP traffic into `%s`: %s", flags.Record, err.Error())
+		}
+		fmt.Println("Recording HTTP traffic into " + flags.Record)
+	}
+	if len(flags.Replay) > 0 {
+		if err = rest.ReplayHTTP(flags.Replay); err != nil {
+			return nil, fmt.Errorf("unable to replay HTTP traffic from `%s`: %s", flags.Replay, err.Error())
+		}
+		fmt.Println("Replaying HTTP traffic from " + flags.Replay)
+	}
+	if len(flags.Drift) > 0 && len(tailArgs) == 0 && !flags.Exclude {
+		// without explicitly specified resources the drift report covers
+		// the resource types found in the previous export
+		var prevResources HCLResources
+		if prevResources, err = ReadHCLResources(flags.Drift); err != nil {
+			return nil, fmt.Errorf("unable to read previous export `%s`: %s", flags.Drift, err.Error())
+		}
+		for _, resourceType := range prevResources.ResourceTypes() {
+			if key, _ := ValidateResource(resourceType); len(key) > 0 {
+				tailArgs = append(tailArgs, key)
+			}
+		}
+		if len(tailArgs) == 0 {
+			return nil, fmt.Errorf("the previous export `%s` doesn't contain any resources", flags.Drift)
+		}
+	}
+
+	filters := map[ResourceType][]*ResourceFilter{}
+	resourceTailArgs := []string{}
+	for _, tailArg := range tailArgs {
+		if !IsResourceFilter(tailArg) {
+			resourceTailArgs = append(resourceTailArgs, tailArg)
+			continue
+		}
+		if flags.Exclude {
+			return nil, fmt.Errorf("filter `%s` cannot be combined with -exclude", tailArg)
+		}
+		var filter *ResourceFilter
+		if filter, err = ParseResourceFilter(tailArg); err != nil {
+			return nil, err
+		}
+		if filter.ResourceType.IsChildResource() {
+			return nil, fmt.Errorf("filter `%s`: child resources follow the filters of their parent `%s`", tailArg, filter.ResourceType.GetParent())
+		}
+		if _, found := filters[filter.ResourceType]; !found {
+			resourceTailArgs = append(resourceTailArgs, string(filter.ResourceType))
+		}
+		filters[filter.ResourceType] = append(filters[filter.ResourceType], filter)
+	}
+	tailArgs = resourceTailArgs
+
 	resArgs := map[string][]string{}
 	if flags.Exclude {
 		for resourceType := range AllResources {
@@ -151,12 +254,34 @@ func Initialize() (environment *Environment, err error) {
 		}
 	}
 
-	targetFolder := os.Getenv("DYNATRACE_TARGET_FOLDER")
+	if config != nil {
+		for _, excluded := range config.Exclude {
+			delete(resArgs, excluded)
+		}
+	}
+
+	targetFolder := config.GetTargetFolder()
 	if targetFolder == "" {
 		fmt.Println("The environment variable DYNATRACE_TARGET_FOLDER has not been set - using folder 'configuration' as default")
 		targetFolder = "configuration"
 	}
-	if os.Getenv("DYNATRACE_CLEAN_TARGET_FOLDER") == "true" {
+	if len(flags.Drift) > 0 {
+		if absTarget, absDrift := absPath(targetFolder), absPath(flags.Drift); absTarget == absDrift {
+			return nil, fmt.Errorf("-drift requires DYNATRACE_TARGET_FOLDER to differ from the previous export folder `%s`", flags.Drift)
+		}
+	}
+	outputFolder := targetFolder
+	if flags.Incremental {
+		if QUICK_INIT {
+			return nil, errors.New("-incremental cannot be combined with DYNATRACE_QUICK_INIT")
+		}
+		// files of resources which don't exist anymore are getting removed anyways
+		// cleaning the target folder would only throw away the objects fetched previously
+		outputFolder = incrementalStagingFolder(targetFolder)
+		os.RemoveAll(outputFolder)
+		cache.EnableIncremental(incrementalCacheFolder(targetFolder))
+	} else if config.IsCleanTargetFolder() && !flags.SchemaCheck {
+		// the schema check only writes its report - previously exported configuration stays untouched
 		os.RemoveAll(targetFolder)
 	}
 
@@ -164,6 +289,17 @@ func Initialize() (environment *Environment, err error) {
 	if credentials, err = settings.CreateExportCredentials(); err != nil {
 		return nil, err
 	}
+	if len(flags.Replay) == 0 && !flags.SchemaCheck {
+		// the export continues regardless - this only spares users from
+		// finding out about the missing scopes resource type by resource type
+		resourceTypes := []ResourceType{}
+		for resourceType := range resArgs {
+			resourceTypes = append(resourceTypes, ResourceType(resourceType))
+		}
+		if missingScopes := CheckScopes(context.Background(), credentials, resourceTypes, false); len(missingScopes) > 0 {
+			fmt.Printf("The configured credentials lack scopes required for exporting %d resource types:\n%s\n", missingScopes.ResourceTypes(), missingScopes.String())
+		}
+	}
 
 	// If ONLY child resources are getting exported we
 	// don't treat them as such. Request from Omar Zaal
@@ -180,12 +316,15 @@ func Initialize() (environment *Environment, err error) {
 	}
 
 	return &Environment{
-		OutputFolder:          targetFolder,
-		Credentials:           credentials,
-		Modules:               map[ResourceType]*Module{},
-		Flags:                 flags,
-		ResArgs:               resArgs,
-		ChildResourceOverride: requestingOnlyChildResources,
+		OutputFolder:            outputFolder,
+		Credentials:             credentials,
+		Modules:                 map[ResourceType]*Module{},
+		Flags:                   flags,
+		ResArgs:                 resArgs,
+		ChildResourceOverride:   requestingOnlyChildResources,
+		Filters:                 filters,
+		IncrementalTargetFolder: targetFolder,
+		Layout:                  layout,
 	}, nil
 }
 
@@ -203,9 +342,23 @@ func createFlags() (flags Flags, tailArgs []string) {
 	importState := flag.Bool("import-state", false, "automatically initialize the terraform module and import downloaded resources to the state")
 	exclude := flag.Bool("exclude", false, "exclude specified resources")
 	skipTerraformInit := flag.Bool("skip-terraform-init", false, "prevent the command line `terraform init` from getting executed after all the configuration files have been created")
+	incremental := flag.Bool("incremental", false, "keep the settings objects fetched across runs, download only new or modified ones and rewrite only the affected files")
+	tfJSON := flag.Bool("json", false, "write the configuration files in Terraform JSON syntax (.tf.json) instead of native HCL")
+	layout := flag.String("layout", LayoutKeys.ResourceType, "organize the exported resources into modules by type, management-zone or owner. flat is equivalent to -flat")
+	drift := flag.String("drift", "", "compare the environment against the previous export in the given folder and write a drift report")
+	record := flag.String("record", "", "record every HTTP request and response sent during the export into the given folder")
+	replay := flag.String("replay", "", "run the export offline, answering HTTP requests from a folder created via -record. the environment URL needs to match the recorded one")
+	schemaCheck := flag.Bool("schema-check", false, "instead of exporting compare the Settings 2.0 schemas of the environment against the ones bundled with the provider and report added, removed and changed properties and enum values")
+	diffCheck := flag.Bool("diff-check", false, "after exporting plan the generated configuration against the settings read from the environment and report attributes `terraform plan` would update in-place right after an import")
+	config := flag.String("config", "", "read the export configuration from the given .hcl or .json file. flags and environment variables take precedence")
 
 	flag.Parse()
 
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
 	importStateFlag := (importState != nil && *importState == true) || (importStateV2 != nil && *importStateV2 == true)
 
 	return Flags{
@@ -220,9 +373,26 @@ func createFlags() (flags Flags, tailArgs []string) {
 		Exclude:             *exclude,
 		DataSources:         *dataSourceArg,
 		SkipTerraformInit:   *skipTerraformInit,
+		Incremental:         *incremental,
+		JSON:                *tfJSON,
+		Layout:              *layout,
+		Drift:               *drift,
+		Config:              *config,
+		Record:              *record,
+		Replay:              *replay,
+		SchemaCheck:         *schemaCheck,
+		DiffCheck:           *diffCheck,
+		explicit:            explicit,
 	}, flag.Args()
 }
 
+func absPath(folder string) string {
+	if abs, err := filepath.Abs(folder); err == nil {
+		return abs
+	}
+	return folder
+}
+
 func ToParent(keyVal string) string {
 	res1 := ""
 	res2 := ""
@@ -274,4 +444,14 @@ type Flags struct {
 	DataSources         bool
 	SkipTerraformInit   bool
 	Include             bool
+	Incremental         bool
+	JSON                bool
+	Layout              string
+	Drift               string
+	Config              string
+	Record              string
+	Replay              string
+	SchemaCheck         bool
+	DiffCheck           bool
+	explicit            map[string]bool
 }
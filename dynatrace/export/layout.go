@@ -0,0 +1,571 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+// Layout decides how the exported resources are getting organized into Terraform modules.
+// It is getting configured via `-layout`.
+type Layout interface {
+	// Key identifies the layout on the command line
+	Key() string
+	// Inspect gets invoked with the settings of every resource that has been downloaded
+	Inspect(resource *Resource, v settings.Settings)
+	// Group returns the name of the module the given resource belongs to.
+	// It gets invoked after post processing, i.e. the references of the resource are known at that point.
+	// Names taken from the resources (management zones, owners) need to pass through `layoutGroupName`.
+	Group(resource *Resource) string
+}
+
+var LayoutKeys = struct {
+	ResourceType   string
+	Flat           string
+	ManagementZone string
+	Owner          string
+}{
+	"type",
+	"flat",
+	"management-zone",
+	"owner",
+}
+
+// Name of the module for resources the layout isn't able to assign to a group
+const LAYOUT_SHARED_GROUP = "shared"
+
+// Name of the module for resources without owner
+const LAYOUT_UNOWNED_GROUP = "unowned"
+
+// layoutGroupName turns a management zone or owner into the name of a module.
+// Names colliding with the module the layout uses for the remaining resources (`reserved`)
+// get a suffix. `toTerraformName` never produces consecutive underscores, hence the suffixed
+// name can't collide with the name of any other management zone or owner.
+func layoutGroupName(name string, reserved string) string {
+	name = toTerraformName(name)
+	if name == reserved {
+		return name + "__1"
+	}
+	return name
+}
+
+func NewLayout(key string) (Layout, error) {
+	switch key {
+	case "", LayoutKeys.ResourceType:
+		return &resourceTypeLayout{key: LayoutKeys.ResourceType}, nil
+	case LayoutKeys.Flat:
+		return &resourceTypeLayout{key: LayoutKeys.Flat}, nil
+	case LayoutKeys.ManagementZone:
+		return &managementZoneLayout{}, nil
+	case LayoutKeys.Owner:
+		return &ownerLayout{owners: map[*Resource]string{}}, nil
+	}
+	return nil, fmt.Errorf("unknown layout `%s`. supported layouts are `%s`, `%s`, `%s` and `%s`", key, LayoutKeys.ResourceType, LayoutKeys.Flat, LayoutKeys.ManagementZone, LayoutKeys.Owner)
+}
+
+// resourceTypeLayout produces one module per resource type (or no modules at all with `-flat`)
+type resourceTypeLayout struct {
+	key string
+}
+
+func (me *resourceTypeLayout) Key() string {
+	return me.key
+}
+
+func (me *resourceTypeLayout) Inspect(resource *Resource, v settings.Settings) {}
+
+func (me *resourceTypeLayout) Group(resource *Resource) string {
+	return resource.Type.Trim()
+}
+
+// managementZoneLayout produces one module per management zone.
+// Resources are getting grouped with the management zone they are referring to.
+type managementZoneLayout struct{}
+
+func (me *managementZoneLayout) Key() string {
+	return LayoutKeys.ManagementZone
+}
+
+func (me *managementZoneLayout) Inspect(resource *Resource, v settings.Settings) {}
+
+func (me *managementZoneLayout) Group(resource *Resource) string {
+	if isManagementZone(resource.Type) {
+		return layoutGroupName(resource.UniqueName, LAYOUT_SHARED_GROUP)
+	}
+	names := []string{}
+	for _, reference := range resource.ResourceReferences {
+		if isManagementZone(reference.Type) {
+			names = append(names, reference.UniqueName)
+		}
+	}
+	if len(names) == 0 {
+		return LAYOUT_SHARED_GROUP
+	}
+	// resources referring to multiple management zones end up consistently in the same one
+	sort.Strings(names)
+	return layoutGroupName(names[0], LAYOUT_SHARED_GROUP)
+}
+
+func isManagementZone(resourceType ResourceType) bool {
+	return resourceType == ResourceTypes.ManagementZoneV2 || resourceType == ResourceTypes.ManagementZone
+}
+
+// ownerLayout produces one module per owner.
+// The owner is either the `owner` property of a resource (e.g. for dashboards) or an `owner` tag.
+// Ownership teams (`dynatrace_ownership_teams`) end up in the module named after their identifier.
+type ownerLayout struct {
+	mu     sync.Mutex
+	owners map[*Resource]string
+}
+
+func (me *ownerLayout) Key() string {
+	return LayoutKeys.Owner
+}
+
+func (me *ownerLayout) Inspect(resource *Resource, v settings.Settings) {
+	owner := ownerOf(resource.Type, v)
+	if len(owner) == 0 {
+		return
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.owners[resource] = owner
+}
+
+func (me *ownerLayout) Group(resource *Resource) string {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if owner, found := me.owners[resource]; found {
+		return layoutGroupName(owner, LAYOUT_UNOWNED_GROUP)
+	}
+	return LAYOUT_UNOWNED_GROUP
+}
+
+func ownerOf(resourceType ResourceType, v settings.Settings) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	var m any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return ""
+	}
+	if resourceType == ResourceTypes.OwnershipTeams {
+		if identifiers := lookupFilterProperty(m, []string{"identifier"}); len(identifiers) > 0 {
+			return identifiers[0]
+		}
+	}
+	for _, propertyPath := range filterPropertyAliases["owner"] {
+		if owners := lookupFilterProperty(m, strings.Split(propertyPath, ".")); len(owners) > 0 && len(owners[0]) > 0 {
+			return owners[0]
+		}
+	}
+	return ownerTag(m)
+}
+
+// ownerTag searches for tags with the key `owner`.
+// Tags are either getting represented as strings (`owner:team-a`) or as objects with `key` and `value`.
+func ownerTag(v any) string {
+	switch typed := v.(type) {
+	case map[string]any:
+		keys := []string{}
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if strings.EqualFold(key, "tags") {
+				if owner := ownerTagOf(typed[key]); len(owner) > 0 {
+					return owner
+				}
+			}
+		}
+		for _, key := range keys {
+			if owner := ownerTag(typed[key]); len(owner) > 0 {
+				return owner
+			}
+		}
+	case []any:
+		for _, elem := range typed {
+			if owner := ownerTag(elem); len(owner) > 0 {
+				return owner
+			}
+		}
+	}
+	return ""
+}
+
+func ownerTagOf(tags any) string {
+	elems, ok := tags.([]any)
+	if !ok {
+		return ""
+	}
+	for _, elem := range elems {
+		switch tag := elem.(type) {
+		case string:
+			if strings.HasPrefix(tag, "owner:") {
+				return strings.TrimPrefix(tag, "owner:")
+			}
+		case map[string]any:
+			if key, ok := tag["key"].(string); ok && key == "owner" {
+				if value, ok := tag["value"].(string); ok {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// IsGroupedLayout checks whether resources are getting organized into modules by something else than their resource type
+func (me *Environment) IsGroupedLayout() bool {
+	if me.Layout == nil {
+		return false
+	}
+	return me.Layout.Key() != LayoutKeys.ResourceType && me.Layout.Key() != LayoutKeys.Flat
+}
+
+func (me *Environment) GetLayoutFolder(group string) string {
+	return path.Join(me.GetFolder(), "modules", group)
+}
+
+// getLayoutGroup returns the module the resource has been moved to by the layout.
+// Child resources live in the file of their parent.
+func (me *Resource) getLayoutGroup() string {
+	if parent := me.GetParent(); parent != nil {
+		return parent.LayoutGroup
+	}
+	return me.LayoutGroup
+}
+
+type layoutGroup struct {
+	Name      string
+	Resources []*Resource
+	// resources of other groups the resources of this group refer to
+	Imports map[*Resource]bool
+	// resources of this group other groups are referring to
+	Exports map[*Resource]bool
+}
+
+func (me *layoutGroup) SortedImports() []*Resource {
+	return sortedLayoutResources(me.Imports)
+}
+
+func (me *layoutGroup) SortedExports() []*Resource {
+	return sortedLayoutResources(me.Exports)
+}
+
+func sortedLayoutResources(m map[*Resource]bool) []*Resource {
+	resources := []*Resource{}
+	for resource := range m {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type == resources[j].Type {
+			return resources[i].UniqueName < resources[j].UniqueName
+		}
+		return resources[i].Type < resources[j].Type
+	})
+	return resources
+}
+
+// sortedLayoutResourceTypes returns the distinct resource types of the given resources
+func sortedLayoutResourceTypes(resources []*Resource) []ResourceType {
+	resourceTypes := []ResourceType{}
+	seen := map[ResourceType]bool{}
+	for _, resource := range resources {
+		if !seen[resource.Type] {
+			seen[resource.Type] = true
+			resourceTypes = append(resourceTypes, resource.Type)
+		}
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool { return resourceTypes[i] < resourceTypes[j] })
+	return resourceTypes
+}
+
+func (me *Environment) sortedLayoutGroups() []*layoutGroup {
+	groups := []*layoutGroup{}
+	for _, group := range me.layoutGroups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// ApplyLayout moves the configuration files of the post processed resources out of the modules per resource type
+// into the modules determined by the layout.
+// References within the same module are getting rewritten to refer to the resource directly,
+// references to other modules remain variables, which are getting wired up in `main.tf`.
+func (me *Environment) ApplyLayout() error {
+	if !me.IsGroupedLayout() {
+		return nil
+	}
+	fmt.Printf("Arranging resources by %s ...\n", me.Layout.Key())
+
+	contents := map[*Resource][]byte{}
+	children := map[*Resource][]*Resource{}
+	for _, module := range me.Modules {
+		if module.IsReferencedAsDataSource() {
+			continue
+		}
+		for _, resource := range module.Resources {
+			if resource.Status != ResourceStati.PostProcessed || resource.IsReferencedAsDataSource() {
+				continue
+			}
+			if parent := resource.GetParent(); parent != nil {
+				children[parent] = append(children[parent], resource)
+				continue
+			}
+			data, err := resource.ReadFile()
+			if err != nil {
+				// not every resource makes it onto disk
+				continue
+			}
+			contents[resource] = data
+		}
+	}
+
+	me.layoutGroups = map[string]*layoutGroup{}
+	for resource := range contents {
+		name := me.Layout.Group(resource)
+		group, found := me.layoutGroups[name]
+		if !found {
+			group = &layoutGroup{Name: name, Imports: map[*Resource]bool{}, Exports: map[*Resource]bool{}}
+			me.layoutGroups[name] = group
+		}
+		group.Resources = append(group.Resources, resource)
+		resource.LayoutGroup = name
+	}
+
+	for _, module := range me.Modules {
+		if err := os.RemoveAll(module.GetFolder()); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range me.layoutGroups {
+		if err := os.MkdirAll(me.GetLayoutFolder(group.Name), os.ModePerm); err != nil {
+			return err
+		}
+		for _, resource := range group.Resources {
+			fileContents := string(contents[resource])
+			references := append([]*Resource{}, resource.ResourceReferences...)
+			for _, child := range children[resource] {
+				references = append(references, child.ResourceReferences...)
+			}
+			for _, reference := range references {
+				if reference.IsReferencedAsDataSource() {
+					continue
+				}
+				referenceGroup := reference.getLayoutGroup()
+				if len(referenceGroup) == 0 {
+					continue
+				}
+				if referenceGroup != group.Name {
+					group.Imports[reference] = true
+					me.layoutGroups[referenceGroup].Exports[reference] = true
+					continue
+				}
+				direct := fmt.Sprintf("%s.%s.", reference.Type, reference.UniqueName)
+				fileContents = strings.ReplaceAll(fileContents, fmt.Sprintf("var.%s.%s.", reference.Type, reference.UniqueName), direct)
+				fileContents = strings.ReplaceAll(fileContents, fmt.Sprintf("var.%s_%s.value.", reference.Type, reference.UniqueName), direct)
+			}
+			if err := os.WriteFile(resource.GetFile(), []byte(fileContents), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (me *Environment) writeLayoutResourceFiles() error {
+	fmt.Println("Writing ___resources___.tf")
+	for _, group := range me.sortedLayoutGroups() {
+		exports := group.SortedExports()
+		if len(exports) == 0 {
+			continue
+		}
+		var sb strings.Builder
+		if ATOMIC_DEPENDENCIES {
+			for _, resource := range exports {
+				sb.WriteString(fmt.Sprintf("output \"resources_%s_%s\" {\n  value = {\n    value = %s.%s\n  }\n}\n\n", resource.Type, resource.UniqueName, resource.Type, resource.UniqueName))
+			}
+		} else {
+			for _, resourceType := range sortedLayoutResourceTypes(exports) {
+				sb.WriteString(fmt.Sprintf("output \"%s\" {\n  value = {\n", resourceType))
+				for _, resource := range exports {
+					if resource.Type == resourceType {
+						sb.WriteString(fmt.Sprintf("    %s = %s.%s\n", resource.UniqueName, resource.Type, resource.UniqueName))
+					}
+				}
+				sb.WriteString("  }\n}\n\n")
+			}
+		}
+		if err := me.writeLayoutFile(group, "___resources___.tf", sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *Environment) writeLayoutVariablesFiles() error {
+	fmt.Println("Writing ___variables___.tf")
+	for _, group := range me.sortedLayoutGroups() {
+		imports := group.SortedImports()
+		if len(imports) == 0 {
+			continue
+		}
+		var sb strings.Builder
+		if ATOMIC_DEPENDENCIES {
+			for _, resource := range imports {
+				sb.WriteString(fmt.Sprintf("variable \"%s_%s\" {\n  type = any\n}\n\n", resource.Type, resource.UniqueName))
+			}
+		} else {
+			for _, resourceType := range sortedLayoutResourceTypes(imports) {
+				sb.WriteString(fmt.Sprintf("variable \"%s\" {\n  type = any\n}\n\n", resourceType))
+			}
+		}
+		if err := me.writeLayoutFile(group, "___variables___.tf", sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *Environment) writeLayoutDataSourceFiles() error {
+	fmt.Println("Writing ___datasources___.tf")
+	for _, group := range me.sortedLayoutGroups() {
+		declarations := map[string]bool{}
+		modules := map[*Module]bool{}
+		for _, resource := range group.Resources {
+			modules[resource.Module] = true
+			for _, reference := range resource.ResourceReferences {
+				if reference.IsReferencedAsDataSource() {
+					if asDS := AsDataSource(reference); len(asDS) > 0 {
+						declarations[asDS] = true
+					}
+				}
+			}
+		}
+		for _, module := range me.Modules {
+			for _, resource := range module.Resources {
+				if parent := resource.GetParent(); parent != nil && parent.LayoutGroup == group.Name {
+					modules[module] = true
+					for _, reference := range resource.ResourceReferences {
+						if reference.IsReferencedAsDataSource() {
+							if asDS := AsDataSource(reference); len(asDS) > 0 {
+								declarations[asDS] = true
+							}
+						}
+					}
+				}
+			}
+		}
+		for module := range modules {
+			for _, dataSource := range module.SortedDataSources() {
+				declarations[dataSource.Declaration()] = true
+			}
+		}
+		if len(declarations) == 0 {
+			continue
+		}
+		sorted := []string{}
+		for declaration := range declarations {
+			sorted = append(sorted, declaration)
+		}
+		sort.Strings(sorted)
+		if err := me.writeLayoutFile(group, "___datasources___.tf", strings.Join(sorted, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *Environment) writeLayoutProviderFiles() error {
+	fmt.Println("Writing modules ___providers___.tf")
+	for _, group := range me.sortedLayoutGroups() {
+		if err := writeModuleProviderFile(path.Join(me.GetLayoutFolder(group.Name), "___providers___.tf")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (me *Environment) writeLayoutMainFile() error {
+	fmt.Println("Writing main.tf")
+	groups := me.sortedLayoutGroups()
+
+	var sb strings.Builder
+	for _, group := range groups {
+		sb.WriteString(fmt.Sprintf("module \"%s\" {\n", group.Name))
+		sb.WriteString(fmt.Sprintf("  source = \"./modules/%s\"\n", group.Name))
+		imports := group.SortedImports()
+		if ATOMIC_DEPENDENCIES {
+			for _, resource := range imports {
+				sb.WriteString(fmt.Sprintf("  %s_%s = module.%s.resources_%s_%s\n", resource.Type, resource.UniqueName, resource.getLayoutGroup(), resource.Type, resource.UniqueName))
+			}
+		} else {
+			for _, resourceType := range sortedLayoutResourceTypes(imports) {
+				// the module itself isn't one of the sources, otherwise Terraform would detect a cycle
+				sources := []string{}
+				for _, other := range groups {
+					if other == group {
+						continue
+					}
+					for resource := range other.Exports {
+						if resource.Type == resourceType {
+							sources = append(sources, fmt.Sprintf("module.%s.%s", other.Name, resourceType))
+							break
+						}
+					}
+				}
+				if len(sources) == 1 {
+					sb.WriteString(fmt.Sprintf("  %s = %s\n", resourceType, sources[0]))
+				} else {
+					sb.WriteString(fmt.Sprintf("  %s = merge(%s)\n", resourceType, strings.Join(sources, ", ")))
+				}
+			}
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	os.MkdirAll(me.OutputFolder, os.ModePerm)
+	fileName := path.Join(me.OutputFolder, "main.tf")
+	if err := os.WriteFile(fileName, []byte(sb.String()), 0644); err != nil {
+		return err
+	}
+	format(fileName, true)
+	return nil
+}
+
+func (me *Environment) writeLayoutFile(group *layoutGroup, name string, contents string) error {
+	fileName := path.Join(me.GetLayoutFolder(group.Name), name)
+	if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+		return err
+	}
+	format(fileName, true)
+	return nil
+}
@@ -0,0 +1,143 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestApplyLayoutRewritesReferences(t *testing.T) {
+	layout, err := export.NewLayout(export.LayoutKeys.ManagementZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	environment := &export.Environment{
+		OutputFolder: t.TempDir(),
+		Credentials:  &settings.Credentials{},
+		Modules:      map[export.ResourceType]*export.Module{},
+		ResArgs:      map[string][]string{},
+		Layout:       layout,
+	}
+	newResource := func(resourceType export.ResourceType, uniqueName string, contents string, references ...*export.Resource) *export.Resource {
+		resource := environment.Module(resourceType).Resource(uniqueName)
+		resource.UniqueName = uniqueName
+		resource.Status = export.ResourceStati.PostProcessed
+		resource.ResourceReferences = references
+		writeFile(t, resource.GetFile(), contents)
+		return resource
+	}
+	zoneA := newResource(export.ResourceTypes.ManagementZoneV2, "zone_a", `name = "a"`)
+	zoneB := newResource(export.ResourceTypes.ManagementZoneV2, "zone_b", `name = "b"`)
+	alertA := newResource(export.ResourceTypes.Alerting, "alert_a", strings.Join([]string{
+		`management_zone  = var.dynatrace_management_zone_v2.zone_a.legacy_id`,
+		`management_zones = [var.dynatrace_management_zone_v2_zone_a.value.id]`,
+	}, "\n"), zoneA)
+	alertB := newResource(export.ResourceTypes.Alerting, "alert_b", `management_zone = var.dynatrace_management_zone_v2.zone_b.legacy_id`, zoneB)
+	notification := newResource(export.ResourceTypes.EmailNotification, "notification", `profile = var.dynatrace_alerting.alert_b.id`, alertB)
+
+	if err := environment.ApplyLayout(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[*export.Resource]string{
+		// references within the same module refer to the resource directly, regardless of `ATOMIC_DEPENDENCIES`
+		alertA: strings.Join([]string{
+			`management_zone  = dynatrace_management_zone_v2.zone_a.legacy_id`,
+			`management_zones = [dynatrace_management_zone_v2.zone_a.id]`,
+		}, "\n"),
+		alertB: `management_zone = dynatrace_management_zone_v2.zone_b.legacy_id`,
+		// references into other modules remain variables
+		notification: `profile = var.dynatrace_alerting.alert_b.id`,
+	}
+	for resource, contents := range expected {
+		data, err := os.ReadFile(resource.GetFile())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != contents {
+			t.Errorf("%s.%s: expected\n%s\ngot\n%s", resource.Type, resource.UniqueName, contents, string(data))
+		}
+	}
+
+	groups := map[*export.Resource]string{zoneA: "zone_a", alertA: "zone_a", zoneB: "zone_b", alertB: "zone_b", notification: export.LAYOUT_SHARED_GROUP}
+	for resource, group := range groups {
+		if resource.LayoutGroup != group {
+			t.Errorf("expected %s.%s within `%s`, got `%s`", resource.Type, resource.UniqueName, group, resource.LayoutGroup)
+		}
+	}
+	assertExists(t, environment.OutputFolder, filepath.Join("modules", "zone_a", "alert_a.alerting.tf"), true)
+	assertExists(t, environment.OutputFolder, filepath.Join("modules", "alerting"), false)
+}
+
+// taggedSettings carries nothing but tags, which the owner layout detects owners by
+type taggedSettings struct {
+	Tags []string `json:"tags"`
+}
+
+func (me *taggedSettings) Schema() map[string]*schema.Schema      { return map[string]*schema.Schema{} }
+func (me *taggedSettings) MarshalHCL(hcl.Properties) error        { return nil }
+func (me *taggedSettings) UnmarshalHCL(decoder hcl.Decoder) error { return nil }
+
+func TestLayoutReservesGroupNames(t *testing.T) {
+	environment := &export.Environment{Credentials: &settings.Credentials{}, Modules: map[export.ResourceType]*export.Module{}}
+	newResource := func(resourceType export.ResourceType, uniqueName string, references ...*export.Resource) *export.Resource {
+		resource := environment.Module(resourceType).Resource(uniqueName)
+		resource.UniqueName = uniqueName
+		resource.ResourceReferences = references
+		return resource
+	}
+
+	layout, err := export.NewLayout(export.LayoutKeys.ManagementZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone := newResource(export.ResourceTypes.ManagementZoneV2, export.LAYOUT_SHARED_GROUP)
+	expected := map[*export.Resource]string{
+		zone: export.LAYOUT_SHARED_GROUP + "__1",
+		newResource(export.ResourceTypes.Alerting, "zoned", zone):      export.LAYOUT_SHARED_GROUP + "__1",
+		newResource(export.ResourceTypes.Alerting, "shared_1", zone):   export.LAYOUT_SHARED_GROUP + "__1",
+		newResource(export.ResourceTypes.ManagementZoneV2, "shared_1"): "shared_1",
+		newResource(export.ResourceTypes.Alerting, "unzoned"):          export.LAYOUT_SHARED_GROUP,
+	}
+	for resource, group := range expected {
+		if actual := layout.Group(resource); actual != group {
+			t.Errorf("%s.%s: expected `%s`, got `%s`", resource.Type, resource.UniqueName, group, actual)
+		}
+	}
+
+	if layout, err = export.NewLayout(export.LayoutKeys.Owner); err != nil {
+		t.Fatal(err)
+	}
+	owned := newResource(export.ResourceTypes.Alerting, "owned")
+	layout.Inspect(owned, &taggedSettings{Tags: []string{"owner:" + export.LAYOUT_UNOWNED_GROUP}})
+	unowned := newResource(export.ResourceTypes.Alerting, "unowned")
+	layout.Inspect(unowned, &taggedSettings{})
+	if actual := layout.Group(owned); actual != export.LAYOUT_UNOWNED_GROUP+"__1" {
+		t.Errorf("expected the resources owned by `%s` within `%s__1`, got `%s`", export.LAYOUT_UNOWNED_GROUP, export.LAYOUT_UNOWNED_GROUP, actual)
+	}
+	if actual := layout.Group(unowned); actual != export.LAYOUT_UNOWNED_GROUP {
+		t.Errorf("expected resources without owner within `%s`, got `%s`", export.LAYOUT_UNOWNED_GROUP, actual)
+	}
+}
@@ -0,0 +1,155 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/version"
+)
+
+const EXPORT_MANIFEST_FILE = "export-manifest.json"
+
+type Manifest struct {
+	ProviderVersion string             `json:"provider_version"`
+	Environment     string             `json:"environment"`
+	Timestamp       time.Time          `json:"timestamp"`
+	Resources       []ManifestResource `json:"resources"`
+}
+
+type ManifestResource struct {
+	Type              ResourceType         `json:"type"`
+	ID                string               `json:"id"`
+	LegacyID          string               `json:"legacy_id,omitempty"`
+	UniqueName        string               `json:"unique_name"`
+	Status            ResourceStatus       `json:"status"`
+	Error             string               `json:"error,omitempty"`
+	File              string               `json:"file,omitempty"`
+	Flawed            bool                 `json:"flawed,omitempty"`
+	FlawedReasons     []string             `json:"flawed_reasons,omitempty"`
+	RequiresAttention bool                 `json:"requires_attention,omitempty"`
+	Parent            *ManifestReference   `json:"parent,omitempty"`
+	Dependencies      []*ManifestReference `json:"dependencies,omitempty"`
+}
+
+type ManifestReference struct {
+	Type       ResourceType `json:"type"`
+	ID         string       `json:"id"`
+	UniqueName string       `json:"unique_name"`
+}
+
+func newManifestReference(resource *Resource) *ManifestReference {
+	return &ManifestReference{Type: resource.Type, ID: resource.ID, UniqueName: resource.UniqueName}
+}
+
+// CreateManifest lists every resource the export has processed, including the ones that failed to download
+func (me *Environment) CreateManifest() *Manifest {
+	manifest := &Manifest{
+		ProviderVersion: version.Current,
+		Environment:     me.Credentials.URL,
+		Timestamp:       time.Now().UTC(),
+		Resources:       []ManifestResource{},
+	}
+	for _, module := range me.Modules {
+		for _, resource := range module.Resources {
+			if !resource.Status.IsOneOf(ResourceStati.Downloaded, ResourceStati.PostProcessed, ResourceStati.Erronous) {
+				continue
+			}
+			if resource.IsReferencedAsDataSource() {
+				continue
+			}
+			manifestResource := ManifestResource{
+				Type:              resource.Type,
+				ID:                resource.ID,
+				LegacyID:          resource.LegacyID,
+				UniqueName:        resource.UniqueName,
+				Status:            resource.Status,
+				Flawed:            resource.Flawed,
+				FlawedReasons:     resource.FlawedReasons,
+				RequiresAttention: resource.RequiresAttention,
+			}
+			if resource.Error != nil {
+				manifestResource.Error = resource.Error.Error()
+			}
+			if resource.Status != ResourceStati.Erronous {
+				manifestResource.File = me.manifestFile(resource)
+			}
+			if parent := resource.GetParent(); parent != nil {
+				manifestResource.Parent = newManifestReference(parent)
+			}
+			for _, reference := range resource.ResourceReferences {
+				if reference == resource.GetParent() {
+					continue
+				}
+				manifestResource.Dependencies = append(manifestResource.Dependencies, newManifestReference(reference))
+			}
+			sort.Slice(manifestResource.Dependencies, func(i, j int) bool {
+				a := manifestResource.Dependencies[i]
+				b := manifestResource.Dependencies[j]
+				if a.Type == b.Type {
+					return a.ID < b.ID
+				}
+				return a.Type < b.Type
+			})
+			manifest.Resources = append(manifest.Resources, manifestResource)
+		}
+	}
+	sort.Slice(manifest.Resources, func(i, j int) bool {
+		a := manifest.Resources[i]
+		b := manifest.Resources[j]
+		if a.Type == b.Type {
+			return a.ID < b.ID
+		}
+		return a.Type < b.Type
+	})
+	return manifest
+}
+
+// manifestFile returns the path of the file containing the resource, relative to the output folder.
+// Child resources get merged into the file of their parent.
+func (me *Environment) manifestFile(resource *Resource) string {
+	file := resource.GetFile()
+	if parent := resource.GetParent(); parent != nil {
+		file = parent.GetFile()
+	}
+	if me.Flags.JSON {
+		if _, err := os.Stat(file + ".json"); err == nil {
+			file = file + ".json"
+		}
+	}
+	if relFile, err := filepath.Rel(me.OutputFolder, file); err == nil {
+		return filepath.ToSlash(relFile)
+	}
+	return file
+}
+
+func (me *Environment) WriteManifest() error {
+	fmt.Println("Writing " + EXPORT_MANIFEST_FILE)
+	data, err := json.MarshalIndent(me.CreateManifest(), "", "  ")
+	if err != nil {
+		return err
+	}
+	os.MkdirAll(me.OutputFolder, os.ModePerm)
+	return os.WriteFile(path.Join(me.OutputFolder, EXPORT_MANIFEST_FILE), data, 0644)
+}
@@ -0,0 +1,139 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package export_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+func newManifestEnvironment(t *testing.T, flags export.Flags) *export.Environment {
+	t.Helper()
+	return &export.Environment{
+		OutputFolder: t.TempDir(),
+		Credentials:  &settings.Credentials{URL: "https://abc12345.live.dynatrace.com"},
+		Flags:        flags,
+		Modules:      map[export.ResourceType]*export.Module{},
+		ResArgs:      map[string][]string{},
+	}
+}
+
+func TestCreateManifest(t *testing.T) {
+	environment := newManifestEnvironment(t, export.Flags{})
+
+	zone := environment.Module(export.ResourceTypes.ManagementZoneV2).Resource("zone-1")
+	zone.UniqueName = "zone_1"
+	zone.Status = export.ResourceStati.PostProcessed
+
+	alerting := environment.Module(export.ResourceTypes.Alerting)
+	profile := alerting.Resource("profile-2")
+	profile.UniqueName = "profile_2"
+	profile.Status = export.ResourceStati.Downloaded
+	profile.Flawed = true
+	profile.FlawedReasons = []string{"reason"}
+	profile.ResourceReferences = []*export.Resource{zone}
+	failed := alerting.Resource("profile-1")
+	failed.UniqueName = "profile_1"
+	failed.Status = export.ResourceStati.Erronous
+	failed.Error = errors.New("access denied")
+	// only discovered, but never downloaded
+	alerting.Resource("profile-3").Status = export.ResourceStati.Discovered
+
+	notifications := environment.Module(export.ResourceTypes.EmailNotification)
+	notification := notifications.Resource("notification-1")
+	notification.UniqueName = "notification_1"
+	notification.Status = export.ResourceStati.PostProcessed
+	notification.XParent = profile
+	notification.ResourceReferences = []*export.Resource{profile, zone}
+
+	manifest := environment.CreateManifest()
+	if manifest.Environment != "https://abc12345.live.dynatrace.com" {
+		t.Errorf("unexpected environment `%s`", manifest.Environment)
+	}
+	expected := []export.ManifestResource{
+		{
+			Type:       export.ResourceTypes.Alerting,
+			ID:         "profile-1",
+			UniqueName: "profile_1",
+			Status:     export.ResourceStati.Erronous,
+			Error:      "access denied",
+		},
+		{
+			Type:          export.ResourceTypes.Alerting,
+			ID:            "profile-2",
+			UniqueName:    "profile_2",
+			Status:        export.ResourceStati.Downloaded,
+			File:          "modules/alerting/profile_2.alerting.tf",
+			Flawed:        true,
+			FlawedReasons: []string{"reason"},
+			Dependencies: []*export.ManifestReference{
+				{Type: export.ResourceTypes.ManagementZoneV2, ID: "zone-1", UniqueName: "zone_1"},
+			},
+		},
+		{
+			Type:       export.ResourceTypes.EmailNotification,
+			ID:         "notification-1",
+			UniqueName: "notification_1",
+			Status:     export.ResourceStati.PostProcessed,
+			// child resources are getting merged into the file of their parent
+			File:   "modules/alerting/profile_2.alerting.tf",
+			Parent: &export.ManifestReference{Type: export.ResourceTypes.Alerting, ID: "profile-2", UniqueName: "profile_2"},
+			Dependencies: []*export.ManifestReference{
+				{Type: export.ResourceTypes.ManagementZoneV2, ID: "zone-1", UniqueName: "zone_1"},
+			},
+		},
+		{
+			Type:       export.ResourceTypes.ManagementZoneV2,
+			ID:         "zone-1",
+			UniqueName: "zone_1",
+			Status:     export.ResourceStati.PostProcessed,
+			File:       "modules/management_zone_v2/zone_1.management_zone_v2.tf",
+		},
+	}
+	if !reflect.DeepEqual(manifest.Resources, expected) {
+		t.Errorf("expected\n%+v\ngot\n%+v", expected, manifest.Resources)
+	}
+}
+
+func TestCreateManifestJSON(t *testing.T) {
+	environment := newManifestEnvironment(t, export.Flags{JSON: true})
+	module := environment.Module(export.ResourceTypes.Alerting)
+	converted := module.Resource("profile-1")
+	converted.UniqueName = "profile_1"
+	converted.Status = export.ResourceStati.PostProcessed
+	writeFile(t, converted.GetFile()+".json", "{}")
+	// e.g. a resource the conversion into JSON syntax has failed for
+	unconverted := module.Resource("profile-2")
+	unconverted.UniqueName = "profile_2"
+	unconverted.Status = export.ResourceStati.PostProcessed
+
+	files := map[string]string{}
+	for _, resource := range environment.CreateManifest().Resources {
+		files[resource.ID] = resource.File
+	}
+	expected := map[string]string{
+		"profile-1": "modules/alerting/profile_1.alerting.tf.json",
+		"profile-2": "modules/alerting/profile_2.alerting.tf",
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+}
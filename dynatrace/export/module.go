@@ -56,6 +56,7 @@ type Module struct {
 	LegacyIdMap            map[string]*Resource
 	DataSourceLock         *sync.Mutex
 	DescriptorLock         sync.Mutex
+	FilteredIDs            map[string]bool
 }
 
 func (me *Module) GetDescriptor() *ResourceDescriptor {
@@ -373,13 +374,19 @@ func (me *Module) WriteProviderFile(logToScreen bool) (err error) {
 }
 
 func (me *Module) writeProviderFile(specificPath string) error {
-	var err error
-
-	if err = me.MkdirAll(false); err != nil {
+	if err := me.MkdirAll(false); err != nil {
 		return err
 	}
+	return writeModuleProviderFile(me.GetFileSpecificPath("___providers___.tf", specificPath))
+}
+
+// writeModuleProviderFile writes the `required_providers` of a module produced by the export
+func writeModuleProviderFile(fileName string) error {
+	var err error
+
+	os.MkdirAll(filepath.Dir(fileName), os.ModePerm)
 	var outputFile *os.File
-	if outputFile, err = me.CreateFileSpecificPath("___providers___.tf", specificPath); err != nil {
+	if outputFile, err = os.Create(fileName); err != nil {
 		return err
 	}
 	defer func() {
@@ -536,39 +543,8 @@ func (me *Module) WriteDataSourcesFile(logToScreen bool) (err error) {
 		buf.Write([]byte("\n" + ds))
 	}
 	for _, dataSource := range me.SortedDataSources() {
-		dataSourceName := dataSource.Name
-		dataSourceID := dataSource.ID
-		dd, _ := json.Marshal(dataSourceName)
-		if dataSource.Type == string(DataSourceKindTenant) {
-			if _, err = buf.WriteString(`
-			data "dynatrace_tenant" "tenant" {
-			}`); err != nil {
-				return err
-			}
-		} else if dataSource.Type == string(DataSourceKindPolicy) {
-			qualifier := ""
-			dsid := dataSource.ID
-			if strings.Contains(dsid, "#-#environment#-#") {
-				qualifier = fmt.Sprintf("environment = \"%s\"", dsid[strings.LastIndex(dsid, "#-#")+3:])
-			} else if strings.Contains(dsid, "#-#account#-#") {
-				qualifier = fmt.Sprintf("account = \"%s\"", dsid[strings.LastIndex(dsid, "#-#")+3:])
-			}
-
-			if _, err = buf.WriteString(fmt.Sprintf(`			
-			data "dynatrace_iam_policy" "%s" {
-				%s
-				name = "%s"
-			}`, dataSource.UniqueName, qualifier, dataSource.Name)); err != nil {
-				return err
-			}
-		} else {
-			if _, err = buf.WriteString(fmt.Sprintf(`
-				data "dynatrace_entity" "%s" {
-					type = "%s"
-					name = %s
-				}`, dataSourceID, dataSource.Type, string(dd))); err != nil {
-				return err
-			}
+		if _, err = buf.WriteString(dataSource.Declaration()); err != nil {
+			return err
 		}
 	}
 	data := buf.Bytes()
@@ -617,6 +593,10 @@ func (me *Module) ProvideDataSources() (dsm map[string]string, err error) {
 }
 
 func (me *Module) PurgeFolder() (err error) {
+	if me.Environment.IsGroupedLayout() {
+		// the folders per resource type are gone already, but a module produced by the layout may share the name
+		return nil
+	}
 	if me.Environment.Flags.Flat {
 		for _, resource := range me.Resources {
 			os.Remove(resource.GetFile())
@@ -1211,6 +1191,11 @@ func (me *Module) IsBundleImpossible() bool {
 		return true
 	}
 
+	// resources are getting regrouped into different modules after post processing
+	if me.Environment.IsGroupedLayout() {
+		return true
+	}
+
 	if me.Environment.HasDependenciesTo[me.Type] {
 		return true
 	}
@@ -1278,6 +1263,10 @@ func (me *Module) Discover() error {
 		return err
 	}
 	stubs = stubs.Sort()
+	if err = me.filter(stubs); err != nil {
+		return err
+	}
+	filteredCount := 0
 	for _, stub := range stubs {
 		if stub.Name == "" {
 			panic(me.Type)
@@ -1286,6 +1275,15 @@ func (me *Module) Discover() error {
 			fmt.Printf("Ignoring Resource - Type: %s - ID: %s\n", me.Type, stub.ID)
 			continue
 		}
+		// resources not passing the filters don't get registered and therefore also not downloaded
+		filteredOut, err := me.isFilteredOut(stub.ID, stub.ParentID)
+		if err != nil {
+			return err
+		}
+		if filteredOut {
+			filteredCount++
+			continue
+		}
 		res := me.Resource(stub.ID).SetName(stub.Name)
 		if stub.LegacyID != nil {
 			res.LegacyID = *stub.LegacyID
@@ -1294,6 +1292,9 @@ func (me *Module) Discover() error {
 			res.ParentID = stub.ParentID
 		}
 	}
+	if filteredCount > 0 {
+		logging.Debug.Info.Printf("[FILTER] [%s] %d of %d items match the filters.", me.Type, len(stubs)-filteredCount, len(stubs))
+	}
 	me.Status = ModuleStati.Discovered
 	hide(stubs)
 
@@ -1426,6 +1427,9 @@ func (me *Module) ExecuteImportV2(fs afero.Fs) (resList resources, err error) {
 		if me.GetDescriptor().Parent != nil {
 			moduleValue = fmt.Sprintf("module.%s", me.GetDescriptor().Parent.Trim())
 		}
+		if group := res.getLayoutGroup(); len(group) > 0 {
+			moduleValue = fmt.Sprintf("module.%s", group)
+		}
 		if res.SplitId > 0 {
 			moduleValue = fmt.Sprintf("%s_%d", moduleValue, res.SplitId)
 		}
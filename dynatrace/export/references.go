@@ -0,0 +1,120 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Reference describes how the configuration of a resource refers to monitored entities or to other configuration.
+// References are derived from the `Dependencies` of a `ResourceDescriptor`
+type Reference struct {
+	// EntityType is the type of the monitored entity referred to, e.g. `HOST`. Empty for references to configuration
+	EntityType string
+	// ResourceType is the type of the resource referred to. Empty for references to monitored entities
+	ResourceType ResourceType
+	// ByName is true if the resource gets referred to via its name instead of its ID
+	ByName bool
+	// Pattern matches the parts of a value which are referring to an entity or a resource
+	Pattern *regexp.Regexp
+	// Attribute matches the keys of the attributes the references can be found in. `nil` matches any attribute
+	Attribute *regexp.Regexp
+}
+
+// settingsObjectIDRegex matches the IDs of Settings 2.0 objects
+var settingsObjectIDRegex = regexp.MustCompile(`^vu9U3hXa3q0AAAAB[A-Za-z0-9_-]+$`)
+
+// configIDRegex matches the UUIDs the Configuration API (v1) identifies its configurations with
+var configIDRegex = regexp.MustCompile(`^` + v1ConfigIdRegex.String() + `$`)
+
+// legacyIDRegex matches the numeric IDs management zones used to be identified with before Settings 2.0
+var legacyIDRegex = regexp.MustCompile(`^-?\d{10,}$`)
+
+var anyValueRegex = regexp.MustCompile(`^.+$`)
+
+// attributes returns a regular expression matching the keys of the given attributes and of the attributes nested within them
+func attributes(names ...string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|\.)(` + strings.Join(names, "|") + `)(\.|$)`)
+}
+
+// idReferenceAttributes are the attributes IDs of resources of a specific type are getting stored in.
+// `Dependencies` based on IDs are replacing them anywhere within the configuration. IDs of Settings 2.0 objects,
+// UUIDs and numeric IDs however also show up in attributes unrelated to the dependency (e.g. `insert_after`),
+// hence references via ID are only getting reported for the resource types listed here
+var idReferenceAttributes = map[ResourceType]*regexp.Regexp{
+	ResourceTypes.Alerting:                    attributes("profile", "alerting_profile"),
+	ResourceTypes.AppSecAttackAlerting:        attributes("attack_candidate_based_alerting_profile"),
+	ResourceTypes.AppSecVulnerabilityAlerting: attributes("security_problem_based_alerting_profile"),
+	ResourceTypes.AutomationBusinessCalendar:  attributes("business_calendar"),
+	ResourceTypes.AutomationSchedulingRule:    attributes("rule", "source_rule", "target_rule", "combine", "intersect", "subtract"),
+	ResourceTypes.AWSCredentials:              attributes("credentials_id"),
+	ResourceTypes.AzureCredentials:            attributes("credentials_id"),
+	ResourceTypes.Credentials:                 attributes("creds", "credentials", "credentials_used_for_external_synchronization"),
+	ResourceTypes.FailureDetectionParameters:  attributes("parameter_id"),
+	ResourceTypes.IAMGroup:                    attributes("group", "groups"),
+	ResourceTypes.IAMPolicy:                   attributes("policy", "policies"),
+	ResourceTypes.JSONDashboard:               attributes("dashboard_id"),
+	ResourceTypes.LogProcessing:               attributes("insert_after"),
+	ResourceTypes.ManagementZoneV2:            attributes("management_zone", "management_zones", "mz_id", "zone_id"),
+	ResourceTypes.Policy:                      attributes("policies"),
+	ResourceTypes.RequestAttribute:            attributes("request_attribute", "server_side_request_attribute"),
+	ResourceTypes.RequestNaming:               attributes("ids"),
+	ResourceTypes.SLO:                         attributes("assigned_entities"),
+	ResourceTypes.UpdateWindows:               attributes("maintenance_window"),
+	ResourceTypes.UserGroup:                   attributes("group", "groups"),
+}
+
+// References returns the references the configuration of resources of this kind may contain.
+// Dependencies which are not resolvable via the ID or the name of the resource they refer to are not getting reported.
+// Neither are dependencies via ID to resource types whose IDs can't get attributed to specific attributes
+func (me ResourceDescriptor) References() []Reference {
+	references := []Reference{}
+	for _, dependency := range me.Dependencies {
+		switch dep := dependency.(type) {
+		case *entityds:
+			references = append(references, Reference{EntityType: dep.Type, Pattern: regexp.MustCompile(dep.Pattern)})
+		case *iddep:
+			if attribute, found := idReferenceAttributes[dep.resourceType]; found {
+				references = append(references,
+					Reference{ResourceType: dep.resourceType, Pattern: settingsObjectIDRegex, Attribute: attribute},
+					Reference{ResourceType: dep.resourceType, Pattern: configIDRegex, Attribute: attribute},
+				)
+			}
+		case *legacyID:
+			if attribute, found := idReferenceAttributes[dep.resourceType]; found {
+				references = append(references, Reference{ResourceType: dep.resourceType, Pattern: legacyIDRegex, Attribute: attribute})
+			}
+		case *mgmzdep:
+			references = append(references, Reference{
+				ResourceType: dep.resourceType,
+				ByName:       true,
+				Pattern:      anyValueRegex,
+				Attribute:    regexp.MustCompile(`(^|\.)management_zones?(\.|$)`),
+			})
+		case *reqAttName:
+			references = append(references, Reference{
+				ResourceType: dep.resourceType,
+				ByName:       true,
+				Pattern:      anyValueRegex,
+				Attribute:    regexp.MustCompile(`(^|\.)request_attribute(\.|$)`),
+			})
+		}
+	}
+	return references
+}
@@ -0,0 +1,101 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+)
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType export.ResourceType
+		referenced   export.ResourceType
+		matching     []string
+		nonMatching  []string
+	}{
+		{
+			name:         "management zones of failure detection rules",
+			resourceType: export.ResourceTypes.FailureDetectionRules,
+			referenced:   export.ResourceTypes.ManagementZoneV2,
+			matching:     []string{"management_zones", "conditions.0.management_zones"},
+			nonMatching:  []string{"insert_after", "name", "parameter_id", "conditions.0.text_values"},
+		},
+		{
+			name:         "parameters of failure detection rules",
+			resourceType: export.ResourceTypes.FailureDetectionRules,
+			referenced:   export.ResourceTypes.FailureDetectionParameters,
+			matching:     []string{"parameter_id"},
+			nonMatching:  []string{"insert_after", "management_zones"},
+		},
+		{
+			name:         "alerting profiles of notifications",
+			resourceType: export.ResourceTypes.EmailNotification,
+			referenced:   export.ResourceTypes.Alerting,
+			matching:     []string{"profile"},
+			nonMatching:  []string{"legacy_id", "name", "subject"},
+		},
+		{
+			name:         "ordering of log processing rules",
+			resourceType: export.ResourceTypes.LogProcessing,
+			referenced:   export.ResourceTypes.LogProcessing,
+			matching:     []string{"insert_after"},
+			nonMatching:  []string{"query", "rule_name"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			references := []export.Reference{}
+			for _, reference := range export.AllResources[test.resourceType].References() {
+				if reference.ResourceType == test.referenced {
+					references = append(references, reference)
+				}
+			}
+			if len(references) == 0 {
+				t.Fatalf("expected `%s` to refer to `%s`", test.resourceType, test.referenced)
+			}
+			for _, reference := range references {
+				if reference.Attribute == nil {
+					t.Fatalf("expected the reference to `%s` to be restricted to specific attributes", reference.ResourceType)
+				}
+				for _, key := range test.matching {
+					if !reference.Attribute.MatchString(key) {
+						t.Errorf("expected `%s` to refer to `%s`", key, test.referenced)
+					}
+				}
+				for _, key := range test.nonMatching {
+					if reference.Attribute.MatchString(key) {
+						t.Errorf("expected `%s` not to refer to `%s`", key, test.referenced)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReferencesViaIDAreRestrictedToAttributes(t *testing.T) {
+	for resourceType, descriptor := range export.AllResources {
+		for _, reference := range descriptor.References() {
+			if len(reference.EntityType) == 0 && reference.Attribute == nil {
+				t.Errorf("`%s` refers to `%s` within any attribute", resourceType, reference.ResourceType)
+			}
+		}
+	}
+}
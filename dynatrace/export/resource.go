@@ -50,10 +50,13 @@ type Resource struct {
 	DataSourceReferences            []*DataSource
 	OutputFileAbs                   string
 	Flawed                          bool
+	FlawedReasons                   []string
+	RequiresAttention               bool
 	XParent                         *Resource
 	ParentID                        *string
 	SplitId                         int
 	BundleFilePath                  string
+	LayoutGroup                     string
 	ExtractedIdsPerDependencyModule map[string]map[string]bool
 	ResourceMutex                   *sync.Mutex
 }
@@ -212,6 +215,9 @@ func (me *Resource) getShorterFileName(filename string) string {
 }
 
 func (me *Resource) GetFile() string {
+	if me.LayoutGroup != "" {
+		return path.Join(me.Module.Environment.GetLayoutFolder(me.LayoutGroup), me.GetFileName())
+	}
 	if me.BundleFilePath == "" {
 		return path.Join(me.Module.GetFolder(), me.GetFileName())
 	}
@@ -305,6 +311,10 @@ func (me *Resource) Download() error {
 	name := settings.Name(settngs, me.ID)
 	me.SetName(name)
 
+	if layout := me.Module.Environment.Layout; layout != nil {
+		layout.Inspect(me, settngs)
+	}
+
 	legacyID := settings.GetLegacyID(settngs)
 	if legacyID != nil {
 		me.LegacyID = *legacyID
@@ -320,6 +330,7 @@ func (me *Resource) Download() error {
 		Type:        string(me.Type),
 		TrimmedType: me.Type.Trim(),
 	})
+	me.FlawedReasons = settings.GetFlawedReasons(settngs)
 	comments := settings.FillDemoValues(settngs)
 	comments = append(comments, settings.Validate(settngs)...)
 
@@ -388,6 +399,7 @@ func (me *Resource) Download() error {
 		absdir, _ := filepath.Abs(path.Dir(me.GetAttentionFile()))
 		os.MkdirAll(absdir, os.ModePerm)
 		os.Link(orig, att)
+		me.RequiresAttention = true
 	}
 	if me.Flawed && me.Status != ResourceStati.Erronous {
 		orig, _ := filepath.Abs(me.GetFile())
@@ -0,0 +1,132 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+const SCHEMA_CHECK_REPORT_FILE = "schema-check.json"
+
+// SchemaCheckReport lists how the Settings 2.0 schemas of the environment differ from the ones bundled with the provider
+type SchemaCheckReport struct {
+	Schemas []*SchemaCheck `json:"schemas"`
+}
+
+type SchemaCheck struct {
+	ResourceTypes []ResourceType `json:"resourceTypes"`
+	*validation.Diff
+	Error string `json:"error,omitempty"`
+}
+
+// CheckSchemas fetches the schemas of the requested resources from the environment and compares them
+// against the bundled `schema.json` files. Resources not based on Settings 2.0 are getting skipped.
+func (me *Environment) CheckSchemas() (*SchemaCheckReport, error) {
+	resourceTypes := map[string][]ResourceType{}
+	for sResourceType := range me.ResArgs {
+		resourceType := ResourceType(sResourceType)
+		descriptor, found := AllResources[resourceType]
+		if !found {
+			continue
+		}
+		schemaID := descriptor.Service(me.Credentials).SchemaID()
+		if bundled, err := validation.Load(schemaID); err != nil {
+			return nil, err
+		} else if bundled == nil {
+			continue
+		}
+		resourceTypes[schemaID] = append(resourceTypes[schemaID], resourceType)
+	}
+
+	schemaIDs := []string{}
+	for schemaID := range resourceTypes {
+		schemaIDs = append(schemaIDs, schemaID)
+	}
+	sort.Strings(schemaIDs)
+
+	client := rest.DefaultClient(me.Credentials.URL, me.Credentials.Token)
+	report := &SchemaCheckReport{Schemas: []*SchemaCheck{}}
+	for _, schemaID := range schemaIDs {
+		sort.Slice(resourceTypes[schemaID], func(i, j int) bool { return resourceTypes[schemaID][i] < resourceTypes[schemaID][j] })
+		check := &SchemaCheck{ResourceTypes: resourceTypes[schemaID]}
+		bundled, _ := validation.Load(schemaID)
+		var data json.RawMessage
+		if err := client.Get(fmt.Sprintf("/api/v2/settings/schemas/%s", url.PathEscape(schemaID)), 200).Finish(&data); err != nil {
+			check.Diff = &validation.Diff{SchemaID: schemaID, PreviousVersion: bundled.Version}
+			check.Error = err.Error()
+			report.Schemas = append(report.Schemas, check)
+			continue
+		}
+		live, err := validation.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the schema `%s` of the environment: %s", schemaID, err.Error())
+		}
+		check.Diff = live.Diff(bundled)
+		report.Schemas = append(report.Schemas, check)
+	}
+	return report, nil
+}
+
+// WriteSchemaCheckReport prints the differences between the bundled and the environment's schemas
+// and stores them in `schema-check.json` within the target folder
+func (me *Environment) WriteSchemaCheckReport() error {
+	report, err := me.CheckSchemas()
+	if err != nil {
+		return err
+	}
+	outdated, failed := 0, 0
+	for _, check := range report.Schemas {
+		var resourceTypes []string
+		for _, resourceType := range check.ResourceTypes {
+			resourceTypes = append(resourceTypes, string(resourceType))
+		}
+		if len(check.Error) > 0 {
+			failed++
+			fmt.Printf("%s (%s): %s\n", check.SchemaID, strings.Join(resourceTypes, ", "), check.Error)
+			continue
+		}
+		if check.IsEmpty() && check.Version == check.PreviousVersion {
+			continue
+		}
+		outdated++
+		fmt.Printf("%s (%s): bundled %s, environment %s\n", check.SchemaID, strings.Join(resourceTypes, ", "), check.PreviousVersion, check.Version)
+		fmt.Print(check.Diff.String())
+	}
+
+	if err = os.MkdirAll(me.OutputFolder, os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path.Join(me.OutputFolder, SCHEMA_CHECK_REPORT_FILE), data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Schema check: %d schemas checked, %d differ, %d failed. Details in %s\n", len(report.Schemas), outdated, failed, path.Join(me.OutputFolder, SCHEMA_CHECK_REPORT_FILE))
+	return nil
+}
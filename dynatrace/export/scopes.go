@@ -0,0 +1,339 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/iam"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// CredentialsKind identifies which of the configured credentials the requests for a resource are getting authenticated with
+type CredentialsKind string
+
+var CredentialsKinds = struct {
+	APIToken CredentialsKind
+	Platform CredentialsKind
+	IAM      CredentialsKind
+}{
+	"api-token",
+	"platform",
+	"iam",
+}
+
+func (me CredentialsKind) String() string {
+	switch me {
+	case CredentialsKinds.Platform:
+		return "the OAuth client (`automation_client_id`)"
+	case CredentialsKinds.IAM:
+		return "the IAM OAuth client (`iam_client_id`)"
+	}
+	return "the API token (`dt_api_token`)"
+}
+
+// Scopes are the permissions the credentials need to grant in order to read or modify resources of a specific type
+type Scopes struct {
+	Credentials CredentialsKind
+	Read        []string
+	Write       []string
+}
+
+// Required returns the scopes required for reading resources and, if `write` is `true`, also for modifying them
+func (me *Scopes) Required(write bool) []string {
+	if !write {
+		return me.Read
+	}
+	required := append([]string{}, me.Read...)
+	for _, scope := range me.Write {
+		if !slices.Contains(required, scope) {
+			required = append(required, scope)
+		}
+	}
+	return required
+}
+
+func apiTokenScopes(read []string, write ...string) Scopes {
+	return Scopes{Credentials: CredentialsKinds.APIToken, Read: read, Write: write}
+}
+
+func platformScopes(read []string, write ...string) Scopes {
+	return Scopes{Credentials: CredentialsKinds.Platform, Read: read, Write: write}
+}
+
+func iamScopes(read []string, write ...string) Scopes {
+	return Scopes{Credentials: CredentialsKinds.IAM, Read: read, Write: write}
+}
+
+// resourceScopes contains the scopes of resources which don't follow the scopes derived from their schema ID
+var resourceScopes = map[ResourceType]Scopes{
+	ResourceTypes.AGToken:                       apiTokenScopes([]string{"activeGateTokenManagement.read"}, "activeGateTokenManagement.create", "activeGateTokenManagement.write"),
+	ResourceTypes.ApplicationDataPrivacy:        apiTokenScopes([]string{"ReadConfig"}, "DataPrivacy"),
+	ResourceTypes.RequestAttribute:              apiTokenScopes([]string{"ReadConfig"}, "CaptureRequestData"),
+	ResourceTypes.KeyUserAction:                 apiTokenScopes([]string{"ReadConfig", "entities.read"}, "WriteConfig"),
+	ResourceTypes.Credentials:                   apiTokenScopes([]string{"credentialVault.read"}, "credentialVault.write"),
+	ResourceTypes.CustomDevice:                  apiTokenScopes([]string{"entities.read"}, "entities.write"),
+	ResourceTypes.CustomTags:                    apiTokenScopes([]string{"entities.read"}, "entities.write"),
+	ResourceTypes.NetworkZone:                   apiTokenScopes([]string{"networkZones.read"}, "networkZones.write"),
+	ResourceTypes.SLO:                           apiTokenScopes([]string{"slo.read"}, "slo.write"),
+	ResourceTypes.AppSecAttackAllowlist:         apiTokenScopes([]string{"attacks.read"}, "attacks.write"),
+	ResourceTypes.AppSecAttackRules:             apiTokenScopes([]string{"attacks.read"}, "attacks.write"),
+	ResourceTypes.AppSecAttackSettings:          apiTokenScopes([]string{"attacks.read"}, "attacks.write"),
+	ResourceTypes.AppSecAttackAlerting:          apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+	ResourceTypes.AppSecNotification:            apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+	ResourceTypes.AppSecVulnerabilityAlerting:   apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+	ResourceTypes.AppSecVulnerabilityCode:       apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+	ResourceTypes.AppSecVulnerabilitySettings:   apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+	ResourceTypes.AppSecVulnerabilityThirdParty: apiTokenScopes([]string{"securityProblems.read"}, "securityProblems.write"),
+
+	ResourceTypes.AutomationWorkflow:         platformScopes([]string{"automation:workflows:read"}, "automation:workflows:write"),
+	ResourceTypes.AutomationBusinessCalendar: platformScopes([]string{"automation:calendars:read"}, "automation:calendars:write"),
+	ResourceTypes.AutomationSchedulingRule:   platformScopes([]string{"automation:rules:read"}, "automation:rules:write"),
+	ResourceTypes.Documents:                  platformScopes([]string{"document:documents:read"}, "document:documents:write", "document:documents:delete"),
+	ResourceTypes.DirectShares:               platformScopes([]string{"document:direct-shares:read"}, "document:direct-shares:write", "document:direct-shares:delete"),
+	ResourceTypes.PlatformBucket:             platformScopes([]string{"storage:bucket-definitions:read"}, "storage:bucket-definitions:write", "storage:bucket-definitions:delete"),
+
+	ResourceTypes.IAMUser:             iamScopes([]string{"account-idm-read"}, "account-idm-write"),
+	ResourceTypes.IAMGroup:            iamScopes([]string{"account-idm-read"}, "account-idm-write"),
+	ResourceTypes.IAMPermission:       iamScopes([]string{"account-idm-read"}, "account-idm-write"),
+	ResourceTypes.IAMPolicy:           iamScopes([]string{"account-env-read", "iam-policies-management"}, "iam-policies-management"),
+	ResourceTypes.IAMPolicyBindings:   iamScopes([]string{"account-env-read", "iam-policies-management"}, "iam-policies-management"),
+	ResourceTypes.IAMPolicyBindingsV2: iamScopes([]string{"account-env-read", "iam-policies-management"}, "iam-policies-management"),
+}
+
+// Scopes returns the scopes required for resources of this type.
+// The result is `nil` if the scopes aren't known, e.g. for resources accessing the Cluster API
+func (me ResourceType) Scopes() *Scopes {
+	if scopes, found := resourceScopes[me]; found {
+		return &scopes
+	}
+	descriptor, found := AllResources[me]
+	if !found {
+		return nil
+	}
+	schemaID := descriptor.Service(&settings.Credentials{}).SchemaID()
+	switch {
+	case schemaID == "generic", strings.HasPrefix(schemaID, "builtin:"), strings.HasPrefix(schemaID, "app:"):
+		scopes := apiTokenScopes([]string{"settings.read"}, "settings.write")
+		return &scopes
+	case strings.HasPrefix(schemaID, "v1:config:"):
+		scopes := apiTokenScopes([]string{"ReadConfig"}, "WriteConfig")
+		return &scopes
+	case strings.HasPrefix(schemaID, "v1:synthetic:"):
+		scopes := apiTokenScopes([]string{"ExternalSyntheticIntegration"}, "ExternalSyntheticIntegration")
+		return &scopes
+	}
+	return nil
+}
+
+// MissingScope lists the resource types which can't get managed because the given credentials lack the given scopes
+type MissingScope struct {
+	Credentials   CredentialsKind
+	Scopes        []string
+	ResourceTypes []ResourceType
+}
+
+func (me *MissingScope) String() string {
+	scopes := make([]string, len(me.Scopes))
+	for idx, scope := range me.Scopes {
+		scopes[idx] = "`" + scope + "`"
+	}
+	resourceTypes := make([]string, len(me.ResourceTypes))
+	for idx, resourceType := range me.ResourceTypes {
+		resourceTypes[idx] = string(resourceType)
+	}
+	return fmt.Sprintf("%s lacks %s: %s", me.Credentials, strings.Join(scopes, ", "), strings.Join(resourceTypes, ", "))
+}
+
+type MissingScopes []*MissingScope
+
+// ResourceTypes returns the number of resource types which can't get managed
+func (me MissingScopes) ResourceTypes() int {
+	count := 0
+	for _, missingScope := range me {
+		count += len(missingScope.ResourceTypes)
+	}
+	return count
+}
+
+func (me MissingScopes) String() string {
+	lines := make([]string, len(me))
+	for idx, missingScope := range me {
+		lines[idx] = "  - " + missingScope.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CheckScopes compares the scopes granted to the given credentials with the ones the given resource types require.
+// With `write` being `true` the resources need to be modifiable, otherwise reading them is sufficient.
+// Credentials which aren't configured or whose scopes can't get determined, like platform tokens, are not getting checked
+func CheckScopes(ctx context.Context, credentials *settings.Credentials, resourceTypes []ResourceType, write bool) MissingScopes {
+	granted := GrantedScopes(ctx, credentials)
+
+	missingScopes := map[string]*MissingScope{}
+	for _, resourceType := range resourceTypes {
+		scopes := resourceType.Scopes()
+		if scopes == nil {
+			continue
+		}
+		grantedScopes, found := granted[scopes.Credentials]
+		if !found {
+			continue
+		}
+		missing := []string{}
+		for _, scope := range scopes.Required(write) {
+			if !slices.Contains(grantedScopes, scope) {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		key := string(scopes.Credentials) + " " + strings.Join(missing, " ")
+		missingScope, found := missingScopes[key]
+		if !found {
+			missingScope = &MissingScope{Credentials: scopes.Credentials, Scopes: missing}
+			missingScopes[key] = missingScope
+		}
+		missingScope.ResourceTypes = append(missingScope.ResourceTypes, resourceType)
+	}
+
+	keys := []string{}
+	for key := range missingScopes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := MissingScopes{}
+	for _, key := range keys {
+		missingScope := missingScopes[key]
+		sort.Slice(missingScope.ResourceTypes, func(i, j int) bool {
+			return missingScope.ResourceTypes[i] < missingScope.ResourceTypes[j]
+		})
+		result = append(result, missingScope)
+	}
+	return result
+}
+
+// GrantedScopes determines the scopes granted to the configured credentials.
+// Credentials which aren't configured or whose scopes can't get determined are not contained in the result
+func GrantedScopes(ctx context.Context, credentials *settings.Credentials) map[CredentialsKind][]string {
+	granted := map[CredentialsKind][]string{}
+	if len(credentials.URL) > 0 && len(credentials.Token) > 0 {
+		if scopes, err := apiTokenGrantedScopes(credentials); err != nil {
+			logging.Debug.Warn.Printf("[SCOPES] unable to look up the scopes of the API token: %s", err.Error())
+		} else {
+			granted[CredentialsKinds.APIToken] = scopes
+		}
+	}
+	// the scopes of a platform token are not getting revealed
+	if len(credentials.Automation.PlatformToken) == 0 && len(credentials.Automation.ClientID) > 0 && len(credentials.Automation.TokenURL) > 0 {
+		if scopes, err := oauthGrantedScopes(ctx, credentials, credentials.Automation.ClientID, credentials.Automation.ClientSecret, credentials.Automation.TokenURL); err != nil {
+			logging.Debug.Warn.Printf("[SCOPES] unable to determine the scopes of the OAuth client: %s", err.Error())
+		} else if len(scopes) > 0 {
+			granted[CredentialsKinds.Platform] = scopes
+		}
+	}
+	if len(credentials.IAM.ClientID) > 0 {
+		tokenURL := credentials.IAM.TokenURL
+		if len(tokenURL) == 0 {
+			tokenURL = iam.DefaultTokenURL
+		}
+		if scopes, err := oauthGrantedScopes(ctx, credentials, credentials.IAM.ClientID, credentials.IAM.ClientSecret, tokenURL); err != nil {
+			logging.Debug.Warn.Printf("[SCOPES] unable to determine the scopes of the IAM OAuth client: %s", err.Error())
+		} else if len(scopes) > 0 {
+			granted[CredentialsKinds.IAM] = scopes
+		}
+	}
+	return granted
+}
+
+func apiTokenGrantedScopes(credentials *settings.Credentials) ([]string, error) {
+	var response struct {
+		Scopes []string `json:"scopes"`
+	}
+	payload := struct {
+		Token string `json:"token"`
+	}{credentials.Token}
+	if err := rest.DefaultClient(credentials.URL, credentials.Token).Post("/api/v2/apiTokens/lookup", payload, 200).Finish(&response); err != nil {
+		return nil, err
+	}
+	return response.Scopes, nil
+}
+
+// oauthGrantedScopes requests a bearer token for the given OAuth client and returns the scopes the SSO granted along with it
+func oauthGrantedScopes(ctx context.Context, credentials *settings.Credentials, clientID string, clientSecret string, tokenURL string) ([]string, error) {
+	config := clientcredentials.Config{ClientID: clientID, ClientSecret: clientSecret, TokenURL: tokenURL}
+	token, err := config.Token(credentials.HTTPContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	scope, _ := token.Extra("scope").(string)
+	return strings.Fields(scope), nil
+}
+
+// ReadRootModuleResources returns the `resource` blocks of the `.tf` and `.tf.json` files directly within the given folder.
+// Other than `ReadHCLResources` it doesn't descend into sub folders, which is what Terraform does for the root module
+func ReadRootModuleResources(folder string) (HCLResources, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+	resources := HCLResources{}
+	for _, entry := range entries {
+		if entry.IsDir() || (!strings.HasSuffix(entry.Name(), ".tf") && !strings.HasSuffix(entry.Name(), TF_JSON_EXTENSION)) {
+			continue
+		}
+		fileResources, err := readHCLFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, fileResource := range fileResources {
+			fileResource.File = entry.Name()
+			resources[fileResource.key()] = fileResource
+		}
+	}
+	return resources, nil
+}
+
+// ResourceTypesByEnvironment returns the resource types grouped by the `environment` the resources are assigned to.
+// Resources without `environment` are managed within the environment configured for the provider itself,
+// which is represented by an empty name
+func (me HCLResources) ResourceTypesByEnvironment() map[string][]string {
+	m := map[string]map[string]bool{}
+	for _, res := range me {
+		environment, _ := res.Properties["environment"].(string)
+		if _, found := m[environment]; !found {
+			m[environment] = map[string]bool{}
+		}
+		m[environment][string(res.Type)] = true
+	}
+	result := map[string][]string{}
+	for environment, resourceTypes := range m {
+		result[environment] = sortedKeys(resourceTypes)
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+)
+
+func TestCheckScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/apiTokens/lookup":
+			var payload struct {
+				Token string `json:"token"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Token != "dt0c01.token" || r.Header.Get("Authorization") != "Api-Token dt0c01.token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"scopes": []string{"settings.read", "settings.write", "ReadConfig", "slo.read"}})
+		case "/sso/oauth2/token":
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "bearer", "token_type": "Bearer", "expires_in": 300, "scope": "automation:workflows:read automation:workflows:write"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	credentials := &settings.Credentials{URL: server.URL, Token: "dt0c01.token"}
+	credentials.Automation.ClientID = "client-id"
+	credentials.Automation.ClientSecret = "client-secret"
+	credentials.Automation.TokenURL = server.URL + "/sso/oauth2/token"
+
+	resourceTypes := []export.ResourceType{
+		export.ResourceTypes.Alerting,
+		export.ResourceTypes.AutoTag,
+		export.ResourceTypes.CalculatedServiceMetric,
+		export.ResourceTypes.SLO,
+		export.ResourceTypes.AutomationWorkflow,
+		export.ResourceTypes.Documents,
+		// no IAM OAuth client configured
+		export.ResourceTypes.IAMGroup,
+	}
+
+	expected := export.MissingScopes{
+		{Credentials: export.CredentialsKinds.APIToken, Scopes: []string{"WriteConfig"}, ResourceTypes: []export.ResourceType{export.ResourceTypes.AutoTag, export.ResourceTypes.CalculatedServiceMetric}},
+		{Credentials: export.CredentialsKinds.APIToken, Scopes: []string{"slo.write"}, ResourceTypes: []export.ResourceType{export.ResourceTypes.SLO}},
+		{Credentials: export.CredentialsKinds.Platform, Scopes: []string{"document:documents:read", "document:documents:write", "document:documents:delete"}, ResourceTypes: []export.ResourceType{export.ResourceTypes.Documents}},
+	}
+	if missingScopes := export.CheckScopes(context.Background(), credentials, resourceTypes, true); !reflect.DeepEqual(missingScopes, expected) {
+		t.Errorf("expected\n%s\nactual\n%s", expected, missingScopes)
+	}
+
+	expected = export.MissingScopes{
+		{Credentials: export.CredentialsKinds.Platform, Scopes: []string{"document:documents:read"}, ResourceTypes: []export.ResourceType{export.ResourceTypes.Documents}},
+	}
+	if missingScopes := export.CheckScopes(context.Background(), credentials, resourceTypes, false); !reflect.DeepEqual(missingScopes, expected) {
+		t.Errorf("expected\n%s\nactual\n%s", expected, missingScopes)
+	}
+
+	// the scopes of a platform token are unknown
+	credentials.Automation.PlatformToken = "dt0s16.token"
+	expected = export.MissingScopes{}
+	if missingScopes := export.CheckScopes(context.Background(), credentials, resourceTypes, false); !reflect.DeepEqual(missingScopes, expected) {
+		t.Errorf("expected\n%s\nactual\n%s", expected, missingScopes)
+	}
+}
@@ -0,0 +1,87 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hclgen"
+)
+
+// TF_JSON_EXTENSION is the extension of configuration files written in Terraform JSON syntax (flag `-json`)
+const TF_JSON_EXTENSION = ".tf.json"
+
+// ConvertToJSON replaces every `.tf` file within the output folder with its `.tf.json` equivalent.
+// The export pipeline produces native HCL up to this point, because resolving references and
+// regrouping modules operates on the HCL text.
+// If any of the files can't get converted the export fails and none of the files gets replaced,
+// because a mix of both syntaxes isn't what has been asked for.
+func (me *Environment) ConvertToJSON() error {
+	if !me.Flags.JSON {
+		return nil
+	}
+	fmt.Println("Converting configuration files to Terraform JSON syntax ...")
+	conversions := map[string][]byte{}
+	failures := []string{}
+	err := filepath.Walk(me.OutputFolder, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".terraform" || info.Name() == INCREMENTAL_FOLDER {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(info.Name(), ".tf") {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		jsonData, err := hclgen.ToJSON(data, filePath)
+		if err != nil {
+			logging.Debug.Warn.Printf("[JSON] [%s] %s", filePath, err.Error())
+			failures = append(failures, fmt.Sprintf("%s: %s", filePath, err.Error()))
+			return nil
+		}
+		conversions[filePath] = jsonData
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to convert %d files to Terraform JSON syntax:\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	for filePath, jsonData := range conversions {
+		if err := os.WriteFile(filePath+".json", jsonData, 0644); err != nil {
+			return err
+		}
+		if err := os.Remove(filePath); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("  %d files converted\n", len(conversions))
+	return nil
+}
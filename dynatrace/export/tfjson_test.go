@@ -0,0 +1,58 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package export_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+)
+
+func TestConvertToJSON(t *testing.T) {
+	environment := &export.Environment{OutputFolder: t.TempDir(), Flags: export.Flags{JSON: true}}
+	writeFile(t, filepath.Join(environment.OutputFolder, "main.tf"), "module \"alerting\" {\n  source = \"./modules/alerting\"\n}\n")
+	writeFile(t, filepath.Join(environment.OutputFolder, "modules", "alerting", "profile.alerting.tf"), "resource \"dynatrace_alerting\" \"profile\" {\n  name = \"profile\"\n}\n")
+
+	if err := environment.ConvertToJSON(); err != nil {
+		t.Fatal(err)
+	}
+	assertExists(t, environment.OutputFolder, "main.tf", false)
+	assertExists(t, environment.OutputFolder, "main"+export.TF_JSON_EXTENSION, true)
+	assertExists(t, environment.OutputFolder, filepath.Join("modules", "alerting", "profile.alerting.tf"), false)
+	assertExists(t, environment.OutputFolder, filepath.Join("modules", "alerting", "profile.alerting"+export.TF_JSON_EXTENSION), true)
+}
+
+func TestConvertToJSONFailure(t *testing.T) {
+	environment := &export.Environment{OutputFolder: t.TempDir(), Flags: export.Flags{JSON: true}}
+	writeFile(t, filepath.Join(environment.OutputFolder, "main.tf"), "module \"alerting\" {\n  source = \"./modules/alerting\"\n}\n")
+	writeFile(t, filepath.Join(environment.OutputFolder, "modules", "alerting", "profile.alerting.tf"), "resource \"dynatrace_alerting\" \"profile\" {\n  name = \n")
+
+	err := environment.ConvertToJSON()
+	if err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	if !strings.Contains(err.Error(), "profile.alerting.tf") {
+		t.Errorf("expected the error to name the file which can't get converted, got `%s`", err.Error())
+	}
+	// none of the files gets replaced, not even the ones which could get converted
+	assertExists(t, environment.OutputFolder, "main.tf", true)
+	assertExists(t, environment.OutputFolder, "main"+export.TF_JSON_EXTENSION, false)
+	assertExists(t, environment.OutputFolder, filepath.Join("modules", "alerting", "profile.alerting.tf"), true)
+}
@@ -0,0 +1,282 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// HTTP archives allow for capturing every request/response sent to an environment (`RecordHTTP`)
+// and for answering the very same requests later on without network access (`ReplayHTTP`).
+//
+// An archive is a folder containing one JSON file per distinct request. Requests are getting
+// identified by method, URL and a hash of the request body. Request headers and request bodies are not
+// getting stored. Response headers (except for cookies) and response bodies are getting stored as received,
+// with the exception of the tokens contained in responses to OAuth token requests, which are getting redacted.
+// Responses may nevertheless contain confidential configuration, hence archives need to be treated like the
+// exported configuration itself. Identical requests sent more than once are getting answered in the order
+// they have been recorded, repeating the last response afterwards.
+
+type archiveMode int
+
+const (
+	archiveModeOff archiveMode = iota
+	archiveModeRecord
+	archiveModeReplay
+)
+
+// archivedExchange is a single recorded response
+type archivedExchange struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// archivedRequest holds all the responses recorded for a distinct request
+type archivedRequest struct {
+	Method    string              `json:"method"`
+	URL       string              `json:"url"`
+	BodyHash  string              `json:"bodyHash,omitempty"`
+	Exchanges []*archivedExchange `json:"exchanges"`
+
+	replayed int
+}
+
+type httpArchive struct {
+	mu       sync.Mutex
+	mode     archiveMode
+	folder   string
+	requests map[string]*archivedRequest
+}
+
+var archive = &httpArchive{requests: map[string]*archivedRequest{}}
+
+// RecordHTTP captures every request/response passing the HTTP clients of this provider into the given folder
+func RecordHTTP(folder string) error {
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return err
+	}
+	return archive.enable(archiveModeRecord, folder, nil)
+}
+
+// ReplayHTTP answers requests with the responses recorded in the given folder.
+// No request is reaching the network. Requests which haven't been recorded result in an error
+func ReplayHTTP(folder string) error {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return err
+	}
+	requests := map[string]*archivedRequest{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			return err
+		}
+		var request archivedRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			return fmt.Errorf("unable to read recorded request `%s`: %s", entry.Name(), err.Error())
+		}
+		requests[archiveKey(request.Method, request.URL, request.BodyHash)] = &request
+	}
+	return archive.enable(archiveModeReplay, folder, requests)
+}
+
+func (me *httpArchive) enable(mode archiveMode, folder string, requests map[string]*archivedRequest) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	if me.mode != archiveModeOff {
+		return errors.New("recording and replaying HTTP traffic are mutually exclusive")
+	}
+	me.mode = mode
+	me.folder = folder
+	if requests != nil {
+		me.requests = requests
+	}
+	return nil
+}
+
+func (me *httpArchive) currentMode() archiveMode {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return me.mode
+}
+
+func archiveKey(method string, url string, bodyHash string) string {
+	sum := sha256.Sum256([]byte(method + " " + url + " " + bodyHash))
+	return hex.EncodeToString(sum[:16])
+}
+
+// archiveTransport records or replays HTTP traffic in case `RecordHTTP` or `ReplayHTTP` are in effect.
+// Otherwise requests are just getting passed on
+type archiveTransport struct {
+	base http.RoundTripper
+}
+
+func (me *archiveTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mode := archive.currentMode()
+	if mode == archiveModeOff {
+		return me.base.RoundTrip(req)
+	}
+	bodyHash := ""
+	tokenRequest := false
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// form encoded bodies are OAuth token requests - they contain nothing but credentials,
+		// which don't need to match when replaying
+		tokenRequest = strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+		if len(data) > 0 && !tokenRequest {
+			sum := sha256.Sum256(data)
+			bodyHash = hex.EncodeToString(sum[:])
+		}
+		req.Body = io.NopCloser(bytes.NewBuffer(data))
+	}
+	url := req.URL.String()
+	if mode == archiveModeReplay {
+		return archive.replay(req, url, bodyHash)
+	}
+	resp, err := me.base.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	// responses getting retried anyways are of no use when replaying
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return resp, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewBuffer(data))
+	if tokenRequest {
+		data = redactTokens(data)
+	}
+	if err := archive.record(req.Method, url, bodyHash, resp, data); err != nil {
+		logger.Println("unable to record", req.Method, url+": "+err.Error())
+	}
+	return resp, nil
+}
+
+func (me *httpArchive) record(method string, url string, bodyHash string, resp *http.Response, data []byte) error {
+	exchange := &archivedExchange{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	exchange.Header.Del("Set-Cookie")
+	if utf8.Valid(data) {
+		exchange.Body = string(data)
+	} else {
+		exchange.Body = base64.StdEncoding.EncodeToString(data)
+		exchange.Base64 = true
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	key := archiveKey(method, url, bodyHash)
+	request, found := me.requests[key]
+	if !found {
+		request = &archivedRequest{Method: method, URL: url, BodyHash: bodyHash}
+		me.requests[key] = request
+	}
+	request.Exchanges = append(request.Exchanges, exchange)
+	fileData, err := json.MarshalIndent(request, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(me.folder, key+".json"), fileData, 0644)
+}
+
+// redactedTokens are the fields of OAuth token responses which are not getting recorded
+var redactedTokens = []string{"access_token", "refresh_token", "id_token"}
+
+// redactTokens replaces the tokens within the response to an OAuth token request.
+// The replayed response still contains a token, because the OAuth client refuses responses without one.
+// Responses which can't get parsed are not getting recorded at all
+func redactTokens(data []byte) []byte {
+	var response map[string]any
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil
+	}
+	for _, field := range redactedTokens {
+		if _, found := response[field]; found {
+			response[field] = "REDACTED"
+		}
+	}
+	redacted, err := json.Marshal(response)
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func (me *httpArchive) replay(req *http.Request, url string, bodyHash string) (*http.Response, error) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	request, found := me.requests[archiveKey(req.Method, url, bodyHash)]
+	if !found || len(request.Exchanges) == 0 {
+		errorLogger.Println("REPLAY", req.Method, url, "not recorded")
+		return nil, fmt.Errorf("%s %s has not been recorded in `%s`", req.Method, url, me.folder)
+	}
+	exchange := request.Exchanges[len(request.Exchanges)-1]
+	if request.replayed < len(request.Exchanges) {
+		exchange = request.Exchanges[request.replayed]
+		request.replayed++
+	}
+	data := []byte(exchange.Body)
+	if exchange.Base64 {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(exchange.Body); err != nil {
+			return nil, err
+		}
+	}
+	header := exchange.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// the recorded body may differ from the received one in case of redacted tokens
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+		StatusCode:    exchange.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBuffer(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,97 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchiveRedactsTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/sso/oauth2/token" {
+			w.Write([]byte(`{"access_token":"secret-access-token","token_type":"Bearer","expires_in":300}`))
+			return
+		}
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer server.Close()
+
+	defer func(prev *httpArchive) { archive = prev }(archive)
+	folder := t.TempDir()
+	archive = &httpArchive{requests: map[string]*archivedRequest{}}
+	if err := RecordHTTP(folder); err != nil {
+		t.Fatal(err)
+	}
+	transport := &archiveTransport{base: http.DefaultTransport}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.PostForm(server.URL+"/sso/oauth2/token", url.Values{"client_secret": {"secret-client-secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "secret-access-token") {
+		t.Errorf("the caller is expected to receive the original token, got %s", string(body))
+	}
+	resp, err = client.Get(server.URL + "/api/v2/settings/objects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "secret-") {
+			t.Errorf("`%s` contains credentials: %s", entry.Name(), string(data))
+		}
+	}
+
+	archive = &httpArchive{requests: map[string]*archivedRequest{}}
+	if err := ReplayHTTP(folder); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	resp, err = client.PostForm(server.URL+"/sso/oauth2/token", url.Values{"client_secret": {"another-secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), `"access_token":"REDACTED"`) {
+		t.Errorf("expected the replayed token response to contain a redacted token, got %s", string(body))
+	}
+}
@@ -17,17 +17,21 @@
 
 package rest
 
-import "io"
+import (
+	"io"
+	"net/http"
+)
 
 type ClientFactory func(envURL, apiToken, schemaID string) Client
 
 func DefaultClient(envURL string, apiToken string) Client {
-	return &defaultClient{envURL: envURL, apiToken: apiToken}
+	return &defaultClient{envURL: envURL, apiToken: apiToken, httpClient: HTTPClient()}
 }
 
 type defaultClient struct {
-	envURL   string
-	apiToken string
+	envURL     string
+	apiToken   string
+	httpClient *http.Client
 }
 
 func (me *defaultClient) Get(url string, expectedStatusCodes ...int) Request {
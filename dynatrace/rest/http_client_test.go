@@ -0,0 +1,262 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package rest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newClientCertificate produces a self-signed certificate for client authentication and its private key, both PEM encoded
+func newClientCertificate(t *testing.T, commonName string) (*x509.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certificate, string(certPEM), string(keyPEM)
+}
+
+// baseTransport returns the transport at the bottom of the chain `NewHTTPClient` produces
+func baseTransport(t *testing.T, client *http.Client) *http.Transport {
+	t.Helper()
+	instrumented := client.Transport.(*instrumentedTransport)
+	archived := instrumented.base.(*archiveTransport)
+	retried := archived.base.(*retryTransport)
+	return retried.base.(*http.Transport)
+}
+
+func TestHTTPClientCACertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caPEM), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		options HTTPOptions
+		trusted bool
+	}{
+		{"system certificates only", HTTPOptions{}, false},
+		{"PEM encoded CA", HTTPOptions{CACertificates: caPEM}, true},
+		{"CA file", HTTPOptions{CACertificates: caFile}, true},
+		{"insecure", HTTPOptions{Insecure: true}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := NewHTTPClient(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if test.trusted && err != nil {
+				t.Errorf("expected the server to be trusted, got %s", err.Error())
+			}
+			if !test.trusted && err == nil {
+				t.Error("expected the certificate of the server to be rejected")
+			}
+		})
+	}
+
+	if _, err := NewHTTPClient(HTTPOptions{CACertificates: "-----BEGIN nothing"}); err == nil {
+		t.Error("expected CA certificates without any certificate to be rejected")
+	}
+}
+
+func TestHTTPClientCertificate(t *testing.T) {
+	certificate, certPEM, keyPEM := newClientCertificate(t, "terraform")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+
+	var commonName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonName = r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	client, err := NewHTTPClient(HTTPOptions{CACertificates: caPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected the server to reject a client without certificate")
+	}
+
+	client, err = NewHTTPClient(HTTPOptions{CACertificates: caPEM, ClientCertificate: certPEM, ClientKey: keyPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if commonName != "terraform" {
+		t.Errorf("expected the server to receive the client certificate, got `%s`", commonName)
+	}
+
+	if _, err := NewHTTPClient(HTTPOptions{ClientCertificate: certPEM}); err == nil {
+		t.Error("expected a client certificate without private key to be rejected")
+	}
+}
+
+func TestHTTPClientNoProxy(t *testing.T) {
+	// `HTTPS_PROXY` and `NO_PROXY` of the machine running the tests must not interfere
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+	tests := []struct {
+		name     string
+		options  HTTPOptions
+		env      map[string]string
+		target   string
+		expected string
+	}{
+		{"proxy", HTTPOptions{HTTPSProxy: "http://proxy:3128"}, nil, "https://abc12345.live.dynatrace.com", "http://proxy:3128"},
+		{"no_proxy host", HTTPOptions{HTTPSProxy: "http://proxy:3128", NoProxy: "abc12345.live.dynatrace.com"}, nil, "https://abc12345.live.dynatrace.com", ""},
+		{"no_proxy domain", HTTPOptions{HTTPSProxy: "http://proxy:3128", NoProxy: "internal.example.com,.dynatrace.com"}, nil, "https://abc12345.live.dynatrace.com", ""},
+		{"no_proxy other domain", HTTPOptions{HTTPSProxy: "http://proxy:3128", NoProxy: "internal.example.com"}, nil, "https://abc12345.live.dynatrace.com", "http://proxy:3128"},
+		{"no_proxy IP range", HTTPOptions{HTTPSProxy: "http://proxy:3128", NoProxy: "10.0.0.0/8"}, nil, "https://10.1.2.3", ""},
+		{"no_proxy overriding environment", HTTPOptions{NoProxy: ".dynatrace.com"}, map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"}, "https://abc12345.live.dynatrace.com", ""},
+		{"environment", HTTPOptions{NoProxy: "internal.example.com"}, map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"}, "https://abc12345.live.dynatrace.com", "http://env-proxy:3128"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			client, err := NewHTTPClient(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err := http.NewRequest(http.MethodGet, test.target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxyURL, err := baseTransport(t, client).Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxy := ""
+			if proxyURL != nil {
+				proxy = proxyURL.String()
+			}
+			if proxy != test.expected {
+				t.Errorf("expected proxy `%s`, got `%s`", test.expected, proxy)
+			}
+		})
+	}
+
+	if _, err := NewHTTPClient(HTTPOptions{HTTPSProxy: "http://proxy:port"}); err == nil {
+		t.Error("expected an invalid proxy URL to be rejected")
+	}
+}
+
+func TestHTTPClientHeaders(t *testing.T) {
+	headers := []http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", "curl")
+	req.Header.Set(RequestIDHeader, "preset")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if req.Header.Get("User-Agent") != "curl" {
+		t.Error("the original request is not supposed to get modified")
+	}
+
+	for _, header := range headers {
+		if header.Get("User-Agent") != UserAgent() {
+			t.Errorf("expected User-Agent `%s`, got `%s`", UserAgent(), header.Get("User-Agent"))
+		}
+	}
+	first, second := headers[0].Get(RequestIDHeader), headers[1].Get(RequestIDHeader)
+	if id, err := hex.DecodeString(first); err != nil || len(id) != 16 {
+		t.Errorf("expected a random 128 bit request ID, got `%s`", first)
+	}
+	if first == second {
+		t.Errorf("expected every request to carry its own request ID, got `%s` twice", first)
+	}
+	if requestID := headers[2].Get(RequestIDHeader); requestID != "preset" {
+		t.Errorf("expected a request ID set by the caller to be kept, got `%s`", requestID)
+	}
+}
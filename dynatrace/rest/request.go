@@ -20,7 +20,6 @@ package rest
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -89,6 +88,9 @@ func SetLogWriter(writer io.Writer) error {
 	return nil
 }
 
+// defaultTransport is the original `http.DefaultTransport`, the transport of every HTTP client created via `NewHTTPClient` is cloned from
+var defaultTransport = http.DefaultTransport.(*http.Transport)
+
 var jar = createJar()
 
 func createJar() *cookiejar.Jar {
@@ -129,7 +131,6 @@ type request struct {
 
 func (me *request) authenticate(req *http.Request) {
 	req.Header.Add("Authorization", "Api-Token "+me.client.apiToken)
-	req.Header.Set("User-Agent", "Dynatrace Terraform Provider")
 }
 
 func (me *request) Payload(payload any) Request {
@@ -205,17 +206,6 @@ func (me *request) Raw() ([]byte, error) {
 		}
 		body = bytes.NewBuffer(data)
 	}
-	// if os.Getenv("DT_REST_DEBUG_REQUEST_PAYLOAD") == "true" && me.payload != nil {
-	if len(data) > 0 {
-		logger.Println(me.method, url+"\n    "+string(data))
-	} else {
-		logger.Println(me.method, url)
-	}
-
-	// } else {
-	// logger.Println(me.method, url)
-	// }
-
 	contentType := ""
 	if me.upload != nil {
 		wbody := &bytes.Buffer{}
@@ -244,21 +234,7 @@ func (me *request) Raw() ([]byte, error) {
 
 	httpClient := &http.Client{
 		Jar:       jar,
-		Transport: http.DefaultTransport,
-	}
-	if strings.TrimSpace(os.Getenv("DYNATRACE_HTTP_INSECURE")) == "true" {
-		httpClient.Transport = &http.Transport{
-			ForceAttemptHTTP2:     http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2,
-			Proxy:                 http.DefaultTransport.(*http.Transport).Proxy,
-			DialContext:           http.DefaultTransport.(*http.Transport).DialContext,
-			MaxIdleConns:          http.DefaultTransport.(*http.Transport).MaxIdleConns,
-			IdleConnTimeout:       http.DefaultTransport.(*http.Transport).IdleConnTimeout,
-			TLSHandshakeTimeout:   http.DefaultTransport.(*http.Transport).TLSHandshakeTimeout,
-			ExpectContinueTimeout: http.DefaultTransport.(*http.Transport).ExpectContinueTimeout,
-			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
-		}
-	} else {
-		httpClient.Transport = http.DefaultTransport
+		Transport: me.client.httpClient.Transport,
 	}
 	response, err := me.execute(func() (*http.Response, error) {
 		if res, err = httpClient.Do(req); err != nil {
@@ -280,13 +256,6 @@ func (me *request) Raw() ([]byte, error) {
 	if data, err = io.ReadAll(res.Body); err != nil {
 		return nil, err
 	}
-	if os.Getenv("DYNATRACE_HTTP_RESPONSE") == "true" {
-		if data != nil {
-			logger.Println(res.Status, string(data))
-		} else {
-			logger.Println(res.Status)
-		}
-	}
 	if len(me.expect) > 0 && !me.expect.contains(res.StatusCode) {
 		if len(requestData) > 0 {
 			errorLogger.Println(me.method, url+"\n    "+string(requestData))
@@ -0,0 +1,211 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
+)
+
+// RetryPolicy defines how requests failing with a server error (5xx), a conflict (409)
+// or a connection reset are getting retried. Requests which aren't idempotent (POST, PATCH)
+// are only getting retried if the environment provably hasn't processed them.
+// The backoff between two attempts starts with `MinBackoff` and doubles with every attempt,
+// but never exceeds `MaxBackoff`.
+// Requests exceeding the rate limit (429) are handled separately, based on the rate limit headers.
+type RetryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+const DefaultMaxRetries = 0
+const DefaultMinBackoff = 1 * time.Second
+const DefaultMaxBackoff = 30 * time.Second
+
+var retryPolicy = resolveRetryPolicy()
+var retryPolicyMu sync.RWMutex
+
+// resolveRetryPolicy configures the retry policy based on the environment variables
+// `DYNATRACE_MAX_RETRIES`, `DYNATRACE_MIN_BACKOFF` and `DYNATRACE_MAX_BACKOFF`.
+// The provider configuration replaces it via `SetRetryPolicy`
+func resolveRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{MaxRetries: DefaultMaxRetries, MinBackoff: DefaultMinBackoff, MaxBackoff: DefaultMaxBackoff}
+	if sMaxRetries := strings.TrimSpace(os.Getenv("DYNATRACE_MAX_RETRIES")); len(sMaxRetries) > 0 {
+		if maxRetries, err := strconv.Atoi(sMaxRetries); err == nil && maxRetries >= 0 {
+			policy.MaxRetries = maxRetries
+		}
+	}
+	if sMinBackoff := strings.TrimSpace(os.Getenv("DYNATRACE_MIN_BACKOFF")); len(sMinBackoff) > 0 {
+		if minBackoff, err := time.ParseDuration(sMinBackoff); err == nil && minBackoff > 0 {
+			policy.MinBackoff = minBackoff
+		}
+	}
+	if sMaxBackoff := strings.TrimSpace(os.Getenv("DYNATRACE_MAX_BACKOFF")); len(sMaxBackoff) > 0 {
+		if maxBackoff, err := time.ParseDuration(sMaxBackoff); err == nil && maxBackoff > 0 {
+			policy.MaxBackoff = maxBackoff
+		}
+	}
+	return policy
+}
+
+// SetRetryPolicy replaces the retry policy applied to all requests sent by this provider
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicyMu.Lock()
+	defer retryPolicyMu.Unlock()
+	retryPolicy = policy
+}
+
+// GetRetryPolicy returns the retry policy currently in effect
+func GetRetryPolicy() RetryPolicy {
+	retryPolicyMu.RLock()
+	defer retryPolicyMu.RUnlock()
+	return retryPolicy
+}
+
+// Backoff returns how long to wait before the given (zero based) retry attempt
+func (me RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := me.MinBackoff
+	for i := 0; i < attempt && backoff < me.MaxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > me.MaxBackoff {
+		return me.MaxBackoff
+	}
+	return backoff
+}
+
+// idempotentMethods are the methods whose requests can get repeated without changing the outcome
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryReason returns why the outcome of a request qualifies for a retry.
+// An empty string signals that the request should not get retried.
+// Requests which aren't idempotent are only getting retried in case the environment hasn't processed them,
+// i.e. after a conflict or in case a gateway responded with an empty `502`, `503` or `504`.
+// Repeating them after any other server error or a connection reset might e.g. create a setting twice
+func retryReason(req *http.Request, resp *http.Response, err error) string {
+	idempotent := idempotentMethods[req.Method]
+	if err != nil {
+		if idempotent && (errors.Is(err, syscall.ECONNRESET) || strings.Contains(err.Error(), "connection reset by peer")) {
+			return "connection reset"
+		}
+		return ""
+	}
+	if resp == nil {
+		return ""
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return resp.Status
+	}
+	if resp.StatusCode < 500 {
+		return ""
+	}
+	if idempotent {
+		return resp.Status
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if hasEmptyBody(resp) {
+			return resp.Status
+		}
+	}
+	return ""
+}
+
+// hasEmptyBody checks whether the given response doesn't contain a body.
+// The body gets restored for whoever is processing the response afterwards
+func hasEmptyBody(resp *http.Response) bool {
+	if resp.Body == nil || resp.Body == http.NoBody || resp.ContentLength == 0 {
+		return true
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return err == nil && len(bytes.TrimSpace(data)) == 0
+}
+
+// retryTransport applies the retry policy to every request passing it.
+// It is part of every HTTP client created via `NewHTTPClient`
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (me *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := GetRetryPolicy()
+	if policy.MaxRetries <= 0 {
+		return me.base.RoundTrip(req)
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := me.base.RoundTrip(req)
+		reason := retryReason(req, resp, err)
+		if len(reason) == 0 || attempt >= policy.MaxRetries || shutdown.System.Stopped() {
+			return resp, err
+		}
+		backoff := policy.Backoff(attempt)
+		message := fmt.Sprintf("%s %s failed with %s - retry %d of %d in %s", req.Method, req.URL, reason, attempt+1, policy.MaxRetries, backoff)
+		log.Printf("[DEBUG] %s", message)
+		logger.Println(message)
+		if resp != nil && resp.Body != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 10, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, test := range tests {
+		if actual := policy.Backoff(test.attempt); actual != test.expected {
+			t.Errorf("attempt %d: expected %s, got %s", test.attempt, test.expected, actual)
+		}
+	}
+	if actual := (RetryPolicy{MinBackoff: time.Minute, MaxBackoff: time.Second}).Backoff(0); actual != time.Second {
+		t.Errorf("expected the backoff never to exceed the maximum, got %s", actual)
+	}
+}
+
+func TestRetryReason(t *testing.T) {
+	response := func(statusCode int, body string) *http.Response {
+		return &http.Response{
+			StatusCode:    statusCode,
+			Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: -1,
+		}
+	}
+	reset := fmt.Errorf("read: %w", syscall.ECONNRESET)
+
+	tests := []struct {
+		name     string
+		method   string
+		resp     *http.Response
+		err      error
+		expected bool
+	}{
+		{"success", http.MethodGet, response(200, "{}"), nil, false},
+		{"client error", http.MethodGet, response(400, "{}"), nil, false},
+		{"rate limit", http.MethodGet, response(429, ""), nil, false},
+		{"conflict", http.MethodPut, response(409, "{}"), nil, true},
+		{"conflict when creating", http.MethodPost, response(409, "{}"), nil, true},
+		{"server error when reading", http.MethodGet, response(500, "{}"), nil, true},
+		{"server error when updating", http.MethodPut, response(500, "{}"), nil, true},
+		{"server error when deleting", http.MethodDelete, response(503, "{}"), nil, true},
+		{"server error when creating", http.MethodPost, response(500, ""), nil, false},
+		{"gateway error with body when creating", http.MethodPost, response(502, `{"error":{"code":502}}`), nil, false},
+		{"empty gateway error when creating", http.MethodPost, response(502, ""), nil, true},
+		{"empty unavailable when creating", http.MethodPost, response(503, "  "), nil, true},
+		{"empty gateway timeout when patching", http.MethodPatch, response(504, ""), nil, true},
+		{"connection reset when reading", http.MethodGet, nil, reset, true},
+		{"connection reset when creating", http.MethodPost, nil, reset, false},
+		{"other error", http.MethodGet, nil, errors.New("no such host"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, "https://localhost/api", nil)
+			if reason := retryReason(req, test.resp, test.err); (len(reason) > 0) != test.expected {
+				t.Errorf("expected retry %v, got reason `%s`", test.expected, reason)
+			}
+		})
+	}
+
+	// the body of the response needs to stay available after inspecting it
+	resp := response(502, `{"error":{"code":502}}`)
+	req, _ := http.NewRequest(http.MethodPost, "https://localhost/api", nil)
+	retryReason(req, resp, nil)
+	if data, _ := io.ReadAll(resp.Body); string(data) != `{"error":{"code":502}}` {
+		t.Errorf("expected the body to be restored, got `%s`", string(data))
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"code":500,"message":"internal error"}}`))
+	}))
+	defer server.Close()
+
+	defer SetRetryPolicy(GetRetryPolicy())
+	SetRetryPolicy(RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	client := &http.Client{Transport: &retryTransport{base: http.DefaultTransport}}
+
+	tests := []struct {
+		method   string
+		expected int32
+	}{
+		{http.MethodGet, 3},
+		{http.MethodPost, 1},
+	}
+	for _, test := range tests {
+		requests.Store(0)
+		req, _ := http.NewRequest(test.method, server.URL, strings.NewReader("{}"))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if actual := requests.Load(); actual != test.expected {
+			t.Errorf("%s: expected %d requests, got %d", test.method, test.expected, actual)
+		}
+	}
+}
@@ -60,6 +60,33 @@ type Validator[T Settings] interface {
 	Validate(v T) error
 }
 
+// UpdateValidator is implemented by services which are able to validate the modification
+// of an existing configuration without applying it
+type UpdateValidator[T Settings] interface {
+	ValidateUpdate(ctx context.Context, id string, v T) error
+}
+
+// ServerSideValidator is implemented by services whose `Validate` lets the Dynatrace environment validate
+// the settings without persisting them. Services wrapping other services forward the capability of the wrapped one.
+// Services not implementing it may validate nothing at all (e.g. because there is no endpoint for that)
+type ServerSideValidator interface {
+	ValidatesServerSide() bool
+}
+
+// ValidatorEndpoint gets embedded by services whose `Validate` sends the settings to the validator endpoint
+// of the Dynatrace environment, which makes them a `ServerSideValidator`
+type ValidatorEndpoint struct{}
+
+func (ValidatorEndpoint) ValidatesServerSide() bool {
+	return true
+}
+
+// ValidatesServerSide checks whether `Validate` of the given service sends the settings to the Dynatrace environment
+func ValidatesServerSide(service any) bool {
+	validator, ok := service.(ServerSideValidator)
+	return ok && validator.ValidatesServerSide()
+}
+
 func NewSettings[T Settings](service RService[T]) T {
 	var proto T
 	return reflect.New(reflect.ValueOf(proto).Type().Elem()).Interface().(T)
@@ -18,11 +18,16 @@
 package settings
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"golang.org/x/oauth2"
 )
 
 const (
@@ -50,17 +55,39 @@ type Credentials struct {
 		ClientID     string
 		AccountID    string
 		ClientSecret string
+		EndpointURL  string
+		TokenURL     string
 	}
 	Automation struct {
 		ClientID       string
 		ClientSecret   string
 		TokenURL       string
 		EnvironmentURL string
+		// PlatformToken authenticates requests to the platform APIs (automation, documents, buckets, apps)
+		// as a bearer token. If specified, the OAuth client credentials aren't required
+		PlatformToken string
 	}
 	Cluster struct {
 		URL   string
 		Token string
 	}
+	// HTTPClient is the HTTP client requests to any of the Dynatrace APIs are getting sent with.
+	// If not specified, the HTTP client shared by all API clients of this provider applies
+	HTTPClient *http.Client
+}
+
+// GetHTTPClient returns the HTTP client requests to any of the Dynatrace APIs are getting sent with
+func (me *Credentials) GetHTTPClient() *http.Client {
+	if me == nil || me.HTTPClient == nil {
+		return rest.HTTPClient()
+	}
+	return me.HTTPClient
+}
+
+// HTTPContext makes OAuth2 clients created based on the returned context
+// send their token requests as well as the authenticated requests via `GetHTTPClient`
+func (me *Credentials) HTTPContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, me.GetHTTPClient())
 }
 
 func getEnv(names ...string) string {
@@ -110,7 +137,7 @@ func CreateExportCredentials() (*Credentials, error) {
 		re := regexp.MustCompile(`https:\/\/(.*).(live|apps).dynatrace.com`)
 		if match := re.FindStringSubmatch(environmentURL); len(match) > 0 {
 			automationEnvironmentURL = fmt.Sprintf("https://%s.apps.dynatrace.com", match[1])
-			automationTokenURL = "https://sso.dynatrace.com/sso/oauth2/token"
+			automationTokenURL = ProdTokenURL
 		}
 	}
 	if len(automationTokenURL) == 0 {
@@ -158,6 +185,11 @@ func CreateExportCredentials() (*Credentials, error) {
 	if apiToken == "" && clusterAPIToken == "" && iam_client_id == "" && iam_client_secret == "" {
 		return nil, errors.New("the environment variable DYNATRACE_API_TOKEN or DYNATRACE_SOURCE_API_TOKEN needs to be set")
 	}
+	httpClient, err := rest.NewHTTPClient(rest.DefaultHTTPOptions())
+	if err != nil {
+		return nil, err
+	}
+	rest.SetHTTPClient(httpClient)
 
 	credentials := &Credentials{
 		URL:   environmentURL,
@@ -166,21 +198,27 @@ func CreateExportCredentials() (*Credentials, error) {
 			ClientID     string
 			AccountID    string
 			ClientSecret string
+			EndpointURL  string
+			TokenURL     string
 		}{
 			ClientID:     iam_client_id,
 			AccountID:    iam_account_id,
 			ClientSecret: iam_client_secret,
+			EndpointURL:  getEnv("IAM_ENDPOINT_URL", "DYNATRACE_IAM_ENDPOINT_URL", "DT_IAM_ENDPOINT_URL"),
+			TokenURL:     getEnv("IAM_TOKEN_URL", "DYNATRACE_IAM_TOKEN_URL", "DT_IAM_TOKEN_URL"),
 		},
 		Automation: struct {
 			ClientID       string
 			ClientSecret   string
 			TokenURL       string
 			EnvironmentURL string
+			PlatformToken  string
 		}{
 			ClientID:       automation_client_id,
 			ClientSecret:   automation_client_secret,
 			EnvironmentURL: automationEnvironmentURL,
 			TokenURL:       automationTokenURL,
+			PlatformToken:  getEnv("DYNATRACE_PLATFORM_TOKEN", "DT_PLATFORM_TOKEN"),
 		},
 		Cluster: struct {
 			URL   string
@@ -189,6 +227,7 @@ func CreateExportCredentials() (*Credentials, error) {
 			URL:   clusterURL,
 			Token: clusterAPIToken,
 		},
+		HTTPClient: httpClient,
 	}
 	return credentials, nil
 }
@@ -209,7 +248,7 @@ func CreateCredentials() (*Credentials, error) {
 		re := regexp.MustCompile(`https:\/\/(.*).(live|apps).dynatrace.com`)
 		if match := re.FindStringSubmatch(environmentURL); len(match) > 0 {
 			automationEnvironmentURL = fmt.Sprintf("https://%s.apps.dynatrace.com", match[1])
-			automationTokenURL = "https://sso.dynatrace.com/sso/oauth2/token"
+			automationTokenURL = ProdTokenURL
 		}
 	}
 	credentials := &Credentials{
@@ -219,22 +258,29 @@ func CreateCredentials() (*Credentials, error) {
 			ClientID     string
 			AccountID    string
 			ClientSecret string
+			EndpointURL  string
+			TokenURL     string
 		}{
 			ClientID:     os.Getenv("DT_CLIENT_ID"),
 			AccountID:    os.Getenv("DT_ACCOUNT_ID"),
 			ClientSecret: os.Getenv("DT_CLIENT_SECRET"),
+			EndpointURL:  getEnv("IAM_ENDPOINT_URL", "DYNATRACE_IAM_ENDPOINT_URL", "DT_IAM_ENDPOINT_URL"),
+			TokenURL:     getEnv("IAM_TOKEN_URL", "DYNATRACE_IAM_TOKEN_URL", "DT_IAM_TOKEN_URL"),
 		},
 		Automation: struct {
 			ClientID       string
 			ClientSecret   string
 			TokenURL       string
 			EnvironmentURL string
+			PlatformToken  string
 		}{
 			ClientID:       os.Getenv("DT_AUTOMATION_CLIENT_ID"),
 			ClientSecret:   os.Getenv("DT_AUTOMATION_CLIENT_SECRET"),
 			EnvironmentURL: automationEnvironmentURL,
 			TokenURL:       automationTokenURL,
+			PlatformToken:  getEnv("DYNATRACE_PLATFORM_TOKEN", "DT_PLATFORM_TOKEN"),
 		},
+		HTTPClient: rest.HTTPClient(),
 	}
 	return credentials, nil
 }
@@ -146,7 +146,7 @@ func (me *defaultService[T]) List(ctx context.Context) (api.Stubs, error) {
 }
 
 func (me *defaultService[T]) Validate(v T) error {
-	if me.options.HasNoValidator {
+	if me.options != nil && me.options.HasNoValidator {
 		return nil
 	}
 	var err error
@@ -160,6 +160,11 @@ func (me *defaultService[T]) Validate(v T) error {
 	return nil
 }
 
+// ValidatesServerSide signals whether `Validate` sends the settings to the validator endpoint of the Dynatrace environment
+func (me *defaultService[T]) ValidatesServerSide() bool {
+	return me.options == nil || !me.options.HasNoValidator
+}
+
 func (me *defaultService[T]) Create(ctx context.Context, v T) (*api.Stub, error) {
 	if me.options != nil && me.options.Lock != nil && me.options.Unlock != nil {
 		me.options.Lock()
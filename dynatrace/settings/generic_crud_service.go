@@ -53,6 +53,20 @@ func (me *GenericCRUDService[T]) Validate(v Settings) error {
 	return nil
 }
 
+// ValidateUpdate validates the modification of the configuration with the given ID.
+// Services not able to validate modifications validate the settings as if they were getting created
+func (me *GenericCRUDService[T]) ValidateUpdate(ctx context.Context, id string, v Settings) error {
+	if validator, ok := me.Service.(UpdateValidator[T]); ok {
+		return validator.ValidateUpdate(ctx, id, v.(T))
+	}
+	return me.Validate(v)
+}
+
+// ValidatesServerSide forwards whether the wrapped service validates the settings server side
+func (me *GenericCRUDService[T]) ValidatesServerSide() bool {
+	return ValidatesServerSide(me.Service)
+}
+
 func (me *GenericCRUDService[T]) Update(ctx context.Context, id string, v Settings) error {
 	return me.Service.Update(ctx, id, v.(T))
 }
@@ -232,6 +232,29 @@ func (me *crudService[T]) Validate(v T) error {
 	return nil
 }
 
+func (me *crudService[T]) ValidateUpdate(ctx context.Context, id string, v T) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if mode == ModeOffline {
+		// Validation by default succeeds in offline mode
+		return nil
+	}
+	if validator, ok := me.service.(settings.UpdateValidator[T]); ok {
+		return validator.ValidateUpdate(ctx, id, v)
+	}
+	if validator, ok := me.service.(settings.Validator[T]); ok {
+		return validator.Validate(v)
+	}
+	return nil
+}
+
+// ValidatesServerSide forwards whether the wrapped service validates the settings server side.
+// In offline mode nothing gets validated
+func (me *crudService[T]) ValidatesServerSide() bool {
+	return mode != ModeOffline && settings.ValidatesServerSide(me.service)
+}
+
 func (me *crudService[T]) storeConfig(id string, name string, v T) error {
 	if err := me.init(); err != nil {
 		return err
@@ -0,0 +1,70 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package cache
+
+import (
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache/tar"
+)
+
+var incrementalFolder string
+var incrementalMu sync.Mutex
+var incrementalFolders = map[string]*tar.Folder{}
+
+// EnableIncremental keeps the settings objects fetched from the environment within the given folder.
+// In contrast to the regular cache that folder survives the current run.
+// Services supporting it only need to fetch objects again, which have been modified since.
+func EnableIncremental(folder string) {
+	incrementalMu.Lock()
+	defer incrementalMu.Unlock()
+	incrementalFolder = folder
+	incrementalFolders = map[string]*tar.Folder{}
+}
+
+func IsIncremental() bool {
+	incrementalMu.Lock()
+	defer incrementalMu.Unlock()
+	return len(incrementalFolder) > 0
+}
+
+// Incremental returns the persistent folder for the given schema.
+// The entries are keyed by object ID, the stored record is up to the service.
+// Returns `nil` in case the incremental mode isn't enabled.
+func Incremental(schemaID string) (*tar.Folder, error) {
+	incrementalMu.Lock()
+	defer incrementalMu.Unlock()
+	if len(incrementalFolder) == 0 {
+		return nil, nil
+	}
+	if folder, found := incrementalFolders[schemaID]; found {
+		return folder, nil
+	}
+	if err := os.MkdirAll(incrementalFolder, os.ModePerm); err != nil {
+		return nil, err
+	}
+	folder, _, err := tar.New(path.Join(incrementalFolder, strings.ReplaceAll(schemaID, ":", ".")))
+	if err != nil {
+		return nil, err
+	}
+	incrementalFolders[schemaID] = folder
+	return folder, nil
+}
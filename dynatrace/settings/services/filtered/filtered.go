@@ -64,6 +64,18 @@ func (me *FilterService[T]) Validate(v T) error {
 	return nil
 }
 
+func (me *FilterService[T]) ValidateUpdate(ctx context.Context, id string, v T) error {
+	if validator, ok := me.Service.(settings.UpdateValidator[T]); ok {
+		return validator.ValidateUpdate(ctx, id, v)
+	}
+	return me.Validate(v)
+}
+
+// ValidatesServerSide forwards whether the wrapped service validates the settings server side
+func (me *FilterService[T]) ValidatesServerSide() bool {
+	return settings.ValidatesServerSide(me.Service)
+}
+
 func (me *FilterService[T]) Get(ctx context.Context, id string, v T) error {
 	return me.Service.Get(ctx, id, v)
 }
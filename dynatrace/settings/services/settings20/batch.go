@@ -0,0 +1,229 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings20
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+)
+
+// DISABLE_BATCHING turns off sending settings objects of the same schema, which are getting created
+// concurrently, within a single request
+var DISABLE_BATCHING = os.Getenv("DYNATRACE_DISABLE_SETTINGS_BATCHING") == "true"
+
+// MAX_BATCH_SIZE is the maximum number of settings objects getting created within a single request
+const MAX_BATCH_SIZE = 100
+
+// batchResponse is the outcome for one of the settings objects sent within a single POST request
+type batchResponse struct {
+	Code     int         `json:"code"`
+	ObjectID string      `json:"objectId"`
+	Error    *rest.Error `json:"error"`
+}
+
+type batchResult struct {
+	objectID string
+	err      error
+}
+
+type batchItem struct {
+	create SettingsObjectCreate
+	result chan batchResult
+}
+
+// batch gathers the settings objects to create with one request
+type batch struct {
+	client rest.Client
+	url    string
+	items  []*batchItem
+}
+
+// batchQueue holds the settings objects of a schema waiting for the request currently in flight to finish
+type batchQueue struct {
+	inFlight int
+	pending  *batch
+}
+
+// batchQueues are keyed by environment, a hash of the credentials, schema and URL
+var batchQueues = struct {
+	sync.Mutex
+	queues map[string]*batchQueue
+}{queues: map[string]*batchQueue{}}
+
+// batchKey identifies the settings objects which can get created with the same request.
+// The credentials are only part of it as a hash, in order not to keep them around as map key
+func batchKey(credentials *settings.Credentials, schemaID string) string {
+	hash := sha256.Sum256([]byte(credentials.Token))
+	return credentials.URL + "|" + hex.EncodeToString(hash[:]) + "|" + schemaID
+}
+
+// createBatched creates a settings object together with the settings objects other resources of the same schema
+// are creating at the same time. If no other request creating settings objects of the same schema is in flight,
+// the settings object gets sent right away. Otherwise it waits for that request to finish and gets sent together
+// with the settings objects which have queued up in the meantime.
+// The Settings 2.0 API reports the outcome for every settings object separately, hence an invalid settings object
+// only fails the resource it belongs to.
+func createBatched(ctx context.Context, client rest.Client, key string, url string, soc SettingsObjectCreate) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	item := &batchItem{create: soc, result: make(chan batchResult, 1)}
+
+	batchQueues.Lock()
+	queue, found := batchQueues.queues[key]
+	if !found {
+		queue = &batchQueue{}
+		batchQueues.queues[key] = queue
+	}
+	var ready *batch
+	if queue.inFlight == 0 {
+		ready = &batch{client: client, url: url, items: []*batchItem{item}}
+	} else {
+		if queue.pending == nil {
+			queue.pending = &batch{client: client, url: url}
+		}
+		queue.pending.items = append(queue.pending.items, item)
+		if len(queue.pending.items) >= MAX_BATCH_SIZE {
+			ready, queue.pending = queue.pending, nil
+		}
+	}
+	if ready != nil {
+		queue.inFlight++
+	}
+	batchQueues.Unlock()
+
+	if ready != nil {
+		go ready.sendAndContinue(key)
+	}
+
+	select {
+	case result := <-item.result:
+		return result.objectID, result.err
+	case <-ctx.Done():
+		if dequeue(key, item) {
+			return "", ctx.Err()
+		}
+		// the settings object is already on its way - its ID is required for the state
+		result := <-item.result
+		return result.objectID, result.err
+	}
+}
+
+// dequeue removes the given settings object from the batch waiting to get sent.
+// It returns false if the settings object isn't waiting anymore, because it has already been sent
+func dequeue(key string, item *batchItem) bool {
+	batchQueues.Lock()
+	defer batchQueues.Unlock()
+	queue, found := batchQueues.queues[key]
+	if !found || queue.pending == nil {
+		return false
+	}
+	for idx, pending := range queue.pending.items {
+		if pending == item {
+			queue.pending.items = append(queue.pending.items[:idx], queue.pending.items[idx+1:]...)
+			if len(queue.pending.items) == 0 {
+				queue.pending = nil
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// sendAndContinue sends the batch and afterwards the settings objects which have queued up in the meantime
+func (me *batch) sendAndContinue(key string) {
+	for b := me; b != nil; {
+		b.send()
+
+		batchQueues.Lock()
+		queue := batchQueues.queues[key]
+		queue.inFlight--
+		b = nil
+		if queue.pending != nil {
+			b, queue.pending = queue.pending, nil
+			queue.inFlight++
+		} else if queue.inFlight == 0 {
+			delete(batchQueues.queues, key)
+		}
+		batchQueues.Unlock()
+	}
+}
+
+func (me *batch) send() {
+	payload := make([]SettingsObjectCreate, len(me.items))
+	for idx, item := range me.items {
+		payload[idx] = item.create
+	}
+	if len(payload) > 1 {
+		logging.Debug.Info.Printf("[BATCH] [%s] creating %d settings objects within a single request", payload[0].SchemaID, len(payload))
+	}
+
+	// the retry transport only repeats this request in case the environment hasn't processed it,
+	// hence the settings objects of a batch don't get created twice
+	var data json.RawMessage
+	if err := me.client.Post(me.url, payload).Expect(200, 207, 400, 404, 409).Finish(&data); err != nil {
+		me.fail(err)
+		return
+	}
+	if len(data) == 0 {
+		if shutdown.System.Stopped() {
+			me.fail(fmt.Errorf("POST %s: aborted because the provider is shutting down", me.url))
+			return
+		}
+		me.fail(fmt.Errorf("POST %s: the response doesn't contain the outcome for the settings objects", me.url))
+		return
+	}
+	var responses []batchResponse
+	if err := json.Unmarshal(data, &responses); err != nil || len(responses) != len(me.items) {
+		if err := rest.Envelope(data, me.url, "POST"); err != nil {
+			me.fail(err)
+			return
+		}
+		me.fail(fmt.Errorf("POST %s: unexpected response: %s", me.url, string(data)))
+		return
+	}
+	for idx, response := range responses {
+		if response.Error != nil {
+			me.items[idx].result <- batchResult{err: rest.Error{
+				Code:                 response.Error.Code,
+				Message:              response.Error.Message,
+				ConstraintViolations: response.Error.ConstraintViolations,
+				Method:               "POST",
+				URL:                  me.url,
+			}}
+			continue
+		}
+		me.items[idx].result <- batchResult{objectID: response.ObjectID}
+	}
+}
+
+func (me *batch) fail(err error) {
+	for _, item := range me.items {
+		item.result <- batchResult{err: err}
+	}
+}
@@ -0,0 +1,207 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings20_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/host/processgroups/monitoringstate"
+	monitoringstatesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/host/processgroups/monitoringstate/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/fake"
+)
+
+// blockingServer forwards requests to the given tenant, but holds back the first request creating settings objects
+// until `release` gets closed. `arrived` gets closed as soon as that request has been received
+func blockingServer(tenant *fake.Tenant, creates *atomic.Int32) (server *httptest.Server, arrived chan struct{}, release chan struct{}) {
+	arrived = make(chan struct{})
+	release = make(chan struct{})
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v2/settings/objects" {
+			if creates.Add(1) == 1 {
+				close(arrived)
+				<-release
+			}
+		}
+		tenant.ServeHTTP(w, r)
+	}))
+	return server, arrived, release
+}
+
+// waitForPendingCreates waits until the given number of settings objects is queued up behind the request in flight
+func waitForPendingCreates(t *testing.T, count int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for settings20.PendingCreates() != count {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d settings objects to be waiting, got %d", count, settings20.PendingCreates())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newMonitoringState(scope string) *monitoringstatesettings.Settings {
+	return &monitoringstatesettings.Settings{
+		HostID:          scope,
+		MonitoringState: monitoringstatesettings.ProcessGroupMonitoringModes.MonitoringOff,
+		ProcessGroup:    "PROCESS_GROUP-0000000000000001",
+	}
+}
+
+func TestCreateBatched(t *testing.T) {
+	tenant := fake.NewTenant()
+	defer tenant.Close()
+	if err := tenant.LoadSchemas("../../../api/builtin/host/processgroups/monitoringstate"); err != nil {
+		t.Fatal(err)
+	}
+	var creates atomic.Int32
+	server, arrived, release := blockingServer(tenant, &creates)
+	defer server.Close()
+
+	scopes := []string{
+		"HOST-0000000000000001",
+		"HOST-0000000000000002",
+		"PROCESS_GROUP-0000000000000003",
+		"HOST-0000000000000004",
+	}
+	ids := make([]string, len(scopes))
+	errs := make([]error, len(scopes))
+	var wg sync.WaitGroup
+	create := func(idx int, scope string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service := monitoringstate.Service(&settings.Credentials{URL: server.URL, Token: fake.Token})
+			stub, err := service.Create(context.Background(), newMonitoringState(scope))
+			if err == nil {
+				ids[idx] = stub.ID
+			}
+			errs[idx] = err
+		}()
+	}
+	// nothing is in flight, hence the first settings object gets sent right away
+	create(0, scopes[0])
+	<-arrived
+	// the others are queuing up until the first request has finished
+	for idx := 1; idx < len(scopes); idx++ {
+		create(idx, scopes[idx])
+	}
+	waitForPendingCreates(t, len(scopes)-1)
+	close(release)
+	wg.Wait()
+
+	if count := creates.Load(); count != 2 {
+		t.Errorf("expected the queued settings objects to get created with a single request, but %d requests have been sent", count)
+	}
+	for idx, scope := range scopes {
+		if strings.HasPrefix(scope, "PROCESS_GROUP") {
+			restErr, ok := errs[idx].(rest.Error)
+			if !ok || !strings.Contains(restErr.ViolationMessage(), fmt.Sprintf("Scope '%s' is not allowed", scope)) {
+				t.Errorf("%s: expected the scope to get rejected, got `%v`", scope, errs[idx])
+			}
+			continue
+		}
+		if errs[idx] != nil {
+			t.Errorf("%s: %s", scope, errs[idx].Error())
+			continue
+		}
+		var created monitoringstatesettings.Settings
+		service := monitoringstate.Service(&settings.Credentials{URL: server.URL, Token: fake.Token})
+		if err := service.Get(context.Background(), ids[idx], &created); err != nil {
+			t.Errorf("%s: %s", scope, err.Error())
+			continue
+		}
+		if created.HostID != scope {
+			t.Errorf("%s: the ID `%s` refers to the settings object for `%s`", scope, ids[idx], created.HostID)
+		}
+	}
+}
+
+func TestCreateBatchedCancelled(t *testing.T) {
+	tenant := fake.NewTenant()
+	defer tenant.Close()
+	if err := tenant.LoadSchemas("../../../api/builtin/host/processgroups/monitoringstate"); err != nil {
+		t.Fatal(err)
+	}
+	var creates atomic.Int32
+	server, arrived, release := blockingServer(tenant, &creates)
+	defer server.Close()
+	service := monitoringstate.Service(&settings.Credentials{URL: server.URL, Token: fake.Token})
+
+	var first error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, first = service.Create(context.Background(), newMonitoringState("HOST-0000000000000001"))
+	}()
+	<-arrived
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var second error
+	cancelled := make(chan struct{})
+	go func() {
+		defer close(cancelled)
+		_, second = service.Create(ctx, newMonitoringState("HOST-0000000000000002"))
+	}()
+	waitForPendingCreates(t, 1)
+	cancel()
+	<-cancelled
+	if !errors.Is(second, context.Canceled) {
+		t.Errorf("expected the waiting settings object to get cancelled, got `%v`", second)
+	}
+	waitForPendingCreates(t, 0)
+
+	close(release)
+	<-done
+	if first != nil {
+		t.Error(first)
+	}
+	if count := creates.Load(); count != 1 {
+		t.Errorf("expected the cancelled settings object not to get sent, but %d requests have been sent", count)
+	}
+	if _, err := service.Create(ctx, newMonitoringState("HOST-0000000000000003")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled context to prevent the create, got `%v`", err)
+	}
+}
+
+func TestCreateBatchedIncompleteResponse(t *testing.T) {
+	for _, body := range []string{"", "null", "[]"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+
+		service := monitoringstate.Service(&settings.Credentials{URL: server.URL, Token: fake.Token})
+		stub, err := service.Create(context.Background(), newMonitoringState("HOST-0000000000000001"))
+		if err == nil {
+			t.Errorf("`%s`: expected an error, got a settings object with ID `%s`", body, stub.ID)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,30 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package settings20
+
+// PendingCreates returns the number of settings objects waiting for a request in flight to finish
+func PendingCreates() int {
+	batchQueues.Lock()
+	defer batchQueues.Unlock()
+	count := 0
+	for _, queue := range batchQueues.queues {
+		if queue.pending != nil {
+			count += len(queue.pending.items)
+		}
+	}
+	return count
+}
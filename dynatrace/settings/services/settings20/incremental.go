@@ -0,0 +1,159 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings20
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/cache"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/shutdown"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+)
+
+// If more than this fraction of the objects has changed, listing them including their values
+// is cheaper than fetching them one by one
+const incrementalMaxChangedRatio = 0.5
+
+// listIncremental resolves the values of the given items via the objects stored by previous runs.
+// Only objects which are new or have been modified since are getting fetched.
+// Returns `nil` if the incremental mode isn't enabled or too many objects have changed.
+func (me *service[T]) listIncremental(listedItems []*SettingsObjectListItem) ([]*SettingsObjectListItem, error) {
+	folder, err := cache.Incremental(me.SchemaID())
+	if err != nil {
+		return nil, err
+	}
+	if folder == nil {
+		return nil, nil
+	}
+
+	items := make([]*SettingsObjectListItem, len(listedItems))
+	unchanged := map[string]bool{}
+	changed := []int{}
+	for idx, listedItem := range listedItems {
+		_, data, err := folder.Get(listedItem.ObjectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			var storedItem SettingsObjectListItem
+			if err = json.Unmarshal(data, &storedItem); err != nil {
+				return nil, err
+			}
+			if !listedItem.IsModifiedSince(&storedItem) {
+				items[idx] = &storedItem
+				unchanged[listedItem.ObjectID] = true
+				continue
+			}
+		}
+		changed = append(changed, idx)
+	}
+	logging.Debug.Info.Printf("[INCREMENTAL] [%s] %d of %d objects are new or have been modified", me.SchemaID(), len(changed), len(listedItems))
+
+	if len(changed) > 0 && float64(len(changed)) > float64(len(listedItems))*incrementalMaxChangedRatio {
+		return nil, nil
+	}
+
+	for _, idx := range changed {
+		if shutdown.System.Stopped() {
+			return items, nil
+		}
+		listedItem := listedItems[idx]
+		var settingsObject SettingsObject
+		if err = me.client.Get(fmt.Sprintf("/api/v2/settings/objects/%s", url.PathEscape(listedItem.ObjectID))).Expect(200).Finish(&settingsObject); err != nil {
+			return nil, err
+		}
+		items[idx] = &SettingsObjectListItem{
+			ObjectID:         listedItem.ObjectID,
+			Scope:            settingsObject.Scope,
+			SchemaVersion:    settingsObject.SchemaVersion,
+			ModificationInfo: settingsObject.ModificationInfo,
+			Value:            settingsObject.Value,
+		}
+		if items[idx].ModificationInfo == nil {
+			items[idx].ModificationInfo = listedItem.ModificationInfo
+		}
+	}
+	if err = me.storeIncremental(items, unchanged); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// storeIncremental remembers the given items for the next run and forgets about
+// the ones which don't exist anymore. Items known to be unchanged don't get written again.
+// If `unchanged` is `nil` it gets determined by comparing with the stored items.
+func (me *service[T]) storeIncremental(items []*SettingsObjectListItem, unchanged map[string]bool) error {
+	folder, err := cache.Incremental(me.SchemaID())
+	if err != nil {
+		return err
+	}
+	if folder == nil {
+		return nil
+	}
+
+	existing := map[string]bool{}
+	for _, item := range items {
+		existing[item.ObjectID] = true
+	}
+	stubs, err := folder.List()
+	if err != nil {
+		return err
+	}
+	for _, stub := range stubs {
+		if !existing[stub.ID] {
+			if err = folder.Delete(stub.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if unchanged == nil {
+		unchanged = map[string]bool{}
+		for _, item := range items {
+			_, data, err := folder.Get(item.ObjectID)
+			if err != nil {
+				return err
+			}
+			if len(data) == 0 {
+				continue
+			}
+			var storedItem SettingsObjectListItem
+			if err = json.Unmarshal(data, &storedItem); err != nil {
+				return err
+			}
+			if !item.IsModifiedSince(&storedItem) {
+				unchanged[item.ObjectID] = true
+			}
+		}
+	}
+
+	return folder.SaveAllCallback(len(items), func(idx int) (api.Stub, []byte, bool, error) {
+		item := items[idx]
+		if unchanged[item.ObjectID] {
+			return api.Stub{}, nil, true, nil
+		}
+		data, err := json.Marshal(item)
+		if err != nil {
+			return api.Stub{}, nil, false, err
+		}
+		return api.Stub{ID: item.ObjectID}, data, false, nil
+	})
+}
@@ -48,8 +48,9 @@ func Service[T settings.Settings](credentials *settings.Credentials, schemaID st
 	return &service[T]{
 		schemaID: schemaID,
 		// schemaVersion: schemaVersion,
-		client:  httpcache.DefaultClient(credentials.URL, credentials.Token, schemaID),
-		options: opts,
+		client:   httpcache.DefaultClient(credentials.URL, credentials.Token, schemaID),
+		options:  opts,
+		batchKey: batchKey(credentials, schemaID),
 	}
 }
 
@@ -76,6 +77,7 @@ type service[T settings.Settings] struct {
 	schemaVersion string
 	client        rest.Client
 	options       *ServiceOptions[T]
+	batchKey      string
 }
 
 func (me *service[T]) LegacyID() func(id string) string {
@@ -190,9 +192,22 @@ func (me *service[T]) getInsertAfter(ids []string, id string) (*string, error) {
 }
 
 func (me *service[T]) listIDs() ([]string, error) {
+	items, err := me.listItems()
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.ObjectID)
+	}
+	return ids, nil
+}
+
+// listItems lists the settings objects of this schema without their values
+func (me *service[T]) listItems() ([]*SettingsObjectListItem, error) {
 	var err error
 
-	ids := []string{}
+	items := []*SettingsObjectListItem{}
 	nextPage := true
 
 	var nextPageKey *string
@@ -202,37 +217,29 @@ func (me *service[T]) listIDs() ([]string, error) {
 		if nextPageKey != nil {
 			urlStr = fmt.Sprintf("/api/v2/settings/objects?nextPageKey=%s", url.QueryEscape(*nextPageKey))
 		} else {
-			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,scope,schemaVersion"))
+			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,scope,schemaVersion,modificationInfo"))
 		}
 		req := me.client.Get(urlStr, 200)
 		if err = req.Finish(&sol); err != nil {
 			return nil, err
 		}
 		if shutdown.System.Stopped() {
-			return ids, nil
+			return items, nil
 		}
 
-		if len(sol.Items) > 0 {
-			for _, item := range sol.Items {
-				ids = append(ids, item.ObjectID)
-			}
-		}
+		items = append(items, sol.Items...)
 		nextPageKey = sol.NextPageKey
 		nextPage = (nextPageKey != nil)
 	}
 
-	return ids, nil
+	return items, nil
 }
 
-func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
+// listValues lists the settings objects of this schema including their values
+func (me *service[T]) listValues() ([]*SettingsObjectListItem, error) {
 	var err error
 
-	ids, err := me.listIDs()
-	if err != nil {
-		return api.Stubs{}, err
-	}
-
-	stubs := api.Stubs{}
+	items := []*SettingsObjectListItem{}
 	nextPage := true
 
 	var nextPageKey *string
@@ -242,59 +249,120 @@ func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
 		if nextPageKey != nil {
 			urlStr = fmt.Sprintf("/api/v2/settings/objects?nextPageKey=%s", url.QueryEscape(*nextPageKey))
 		} else {
-			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,value,scope,schemaVersion"))
+			urlStr = fmt.Sprintf("/api/v2/settings/objects?schemaIds=%s&fields=%s&pageSize=100", url.QueryEscape(me.SchemaID()), url.QueryEscape("objectId,value,scope,schemaVersion,modificationInfo"))
 		}
 		req := me.client.Get(urlStr, 200)
 		if err = req.Finish(&sol); err != nil {
 			return nil, err
 		}
 		if shutdown.System.Stopped() {
-			return stubs, nil
+			return items, nil
 		}
 
-		if len(sol.Items) > 0 {
-			for _, item := range sol.Items {
-				newItem := settings.NewSettings[T](me)
-				if err = json.Unmarshal(item.Value, &newItem); err != nil {
-					return nil, err
-				}
-				if me.options != nil && me.options.LegacyID != nil {
-					settings.SetLegacyID(item.ObjectID, me.options.LegacyID, newItem)
-				}
-				settings.SetScope(newItem, item.Scope)
-				insertBefore, insertAfter, err := me.getInsertIDs(item.ObjectID, ids)
-				if err != nil {
-					return api.Stubs{}, err
-				}
-				if insertBefore != nil {
-					settings.SetInsertBefore(newItem, *insertBefore)
-				}
-				if insertAfter != nil {
-					settings.SetInsertAfter(newItem, *insertAfter)
-				}
-				var itemName string
-				if me.options != nil && me.options.Name != nil {
-					if itemName, err = me.options.Name(item.ObjectID, newItem); err != nil {
-						itemName = settings.Name(newItem, item.ObjectID)
-					}
-				} else {
-					itemName = settings.Name(newItem, item.ObjectID)
-				}
-				stub := &api.Stub{ID: item.ObjectID, Name: itemName, Value: newItem, LegacyID: settings.GetLegacyID(newItem)}
-				if len(itemName) > 0 {
-					stubs = append(stubs, stub)
-				}
-			}
-		}
+		items = append(items, sol.Items...)
 		nextPageKey = sol.NextPageKey
 		nextPage = (nextPageKey != nil)
 	}
 
+	return items, nil
+}
+
+func (me *service[T]) List(ctx context.Context) (api.Stubs, error) {
+	var err error
+
+	listedItems, err := me.listItems()
+	if err != nil {
+		return api.Stubs{}, err
+	}
+	if shutdown.System.Stopped() {
+		return api.Stubs{}, nil
+	}
+	ids := []string{}
+	for _, item := range listedItems {
+		ids = append(ids, item.ObjectID)
+	}
+
+	var items []*SettingsObjectListItem
+	if items, err = me.listIncremental(listedItems); err != nil {
+		return nil, err
+	}
+	if items == nil {
+		if items, err = me.listValues(); err != nil {
+			return nil, err
+		}
+		if shutdown.System.Stopped() {
+			return api.Stubs{}, nil
+		}
+		if err = me.storeIncremental(items, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	stubs := api.Stubs{}
+	for _, item := range items {
+		newItem := settings.NewSettings[T](me)
+		if err = json.Unmarshal(item.Value, &newItem); err != nil {
+			return nil, err
+		}
+		if me.options != nil && me.options.LegacyID != nil {
+			settings.SetLegacyID(item.ObjectID, me.options.LegacyID, newItem)
+		}
+		settings.SetScope(newItem, item.Scope)
+		insertBefore, insertAfter, err := me.getInsertIDs(item.ObjectID, ids)
+		if err != nil {
+			return api.Stubs{}, err
+		}
+		if insertBefore != nil {
+			settings.SetInsertBefore(newItem, *insertBefore)
+		}
+		if insertAfter != nil {
+			settings.SetInsertAfter(newItem, *insertAfter)
+		}
+		var itemName string
+		if me.options != nil && me.options.Name != nil {
+			if itemName, err = me.options.Name(item.ObjectID, newItem); err != nil {
+				itemName = settings.Name(newItem, item.ObjectID)
+			}
+		} else {
+			itemName = settings.Name(newItem, item.ObjectID)
+		}
+		stub := &api.Stub{ID: item.ObjectID, Name: itemName, Value: newItem, LegacyID: settings.GetLegacyID(newItem)}
+		if len(itemName) > 0 {
+			stubs = append(stubs, stub)
+		}
+	}
+
 	return stubs, nil
 }
 
+// Validate sends the settings to the environment with `validateOnly=true`.
+// The environment checks them as if they were getting created, but doesn't persist them
 func (me *service[T]) Validate(v T) error {
-	return nil // Settings 2.0 doesn't offer validation
+	soc := SettingsObjectCreate{
+		SchemaID:      me.schemaID,
+		SchemaVersion: me.schemaVersion,
+		Scope:         settings.GetScope(v),
+		Value:         v,
+	}
+	if me.skipRepairInput() {
+		return me.client.Post("/api/v2/settings/objects?validateOnly=true", []SettingsObjectCreate{soc}).Expect(200).Finish()
+	}
+	return me.client.Post("/api/v2/settings/objects?validateOnly=true&repairInput=true", []SettingsObjectCreate{soc}).Expect(200).Finish()
+}
+
+// ValidatesServerSide signals that `Validate` lets the Settings 2.0 API validate the settings without persisting them
+func (me *service[T]) ValidatesServerSide() bool {
+	return true
+}
+
+// ValidateUpdate sends the modified settings for the settings object with the given ID to the environment
+// with `validateOnly=true`. The settings object itself remains unchanged
+func (me *service[T]) ValidateUpdate(ctx context.Context, id string, v T) error {
+	sou := SettingsObjectUpdate{Value: v, SchemaVersion: me.schemaVersion}
+	if me.skipRepairInput() {
+		return me.client.Put(fmt.Sprintf("/api/v2/settings/objects/%s?validateOnly=true", url.PathEscape(id)), &sou, 200).Finish()
+	}
+	return me.client.Put(fmt.Sprintf("/api/v2/settings/objects/%s?validateOnly=true&repairInput=true", url.PathEscape(id)), &sou, 200).Finish()
 }
 
 func (me *service[T]) Create(ctx context.Context, v T) (*api.Stub, error) {
@@ -373,16 +441,23 @@ func (me *service[T]) create(ctx context.Context, v T, retry bool, noInsertAfter
 		}
 	}
 
-	var req rest.Request
+	createURL := "/api/v2/settings/objects?repairInput=true"
 	if me.skipRepairInput() {
-		req = me.client.Post("/api/v2/settings/objects", []SettingsObjectCreate{soc}).Expect(200)
-	} else {
-		req = me.client.Post("/api/v2/settings/objects?repairInput=true", []SettingsObjectCreate{soc}).Expect(200)
+		createURL = "/api/v2/settings/objects"
 	}
 
 	objectID := []SettingsObjectCreateResponse{}
 
-	if oerr := req.Finish(&objectID); oerr != nil {
+	var oerr error
+	if DISABLE_BATCHING {
+		oerr = me.client.Post(createURL, []SettingsObjectCreate{soc}).Expect(200).Finish(&objectID)
+	} else {
+		var createdID string
+		if createdID, oerr = createBatched(ctx, me.client, me.batchKey+"|"+createURL, createURL, soc); oerr == nil {
+			objectID = append(objectID, SettingsObjectCreateResponse{ObjectID: createdID})
+		}
+	}
+	if oerr != nil {
 		if isInvalidInsertAfter(oerr) {
 			return me.create(ctx, v, retry, true)
 		}
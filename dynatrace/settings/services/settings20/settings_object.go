@@ -22,10 +22,11 @@ import (
 )
 
 type SettingsObject struct {
-	SchemaVersion string          `json:"schemaVersion"`
-	SchemaID      string          `json:"schemaId"`
-	Scope         string          `json:"scope"`
-	Value         json.RawMessage `json:"value"`
+	SchemaVersion    string            `json:"schemaVersion"`
+	SchemaID         string            `json:"schemaId"`
+	Scope            string            `json:"scope"`
+	ModificationInfo *ModificationInfo `json:"modificationInfo,omitempty"`
+	Value            json.RawMessage   `json:"value"`
 }
 
 type SettingsObjectList struct {
@@ -34,8 +35,33 @@ type SettingsObjectList struct {
 }
 
 type SettingsObjectListItem struct {
-	ObjectID      string          `json:"objectId"`
-	Scope         string          `json:"scope"`
-	SchemaVersion string          `json:"schemaVersion"`
-	Value         json.RawMessage `json:"value"`
+	ObjectID         string            `json:"objectId"`
+	Scope            string            `json:"scope"`
+	SchemaVersion    string            `json:"schemaVersion"`
+	ModificationInfo *ModificationInfo `json:"modificationInfo,omitempty"`
+	Value            json.RawMessage   `json:"value,omitempty"`
+}
+
+type ModificationInfo struct {
+	CreatedBy        string `json:"createdBy,omitempty"`
+	CreatedTime      string `json:"createdTime,omitempty"`
+	LastModifiedBy   string `json:"lastModifiedBy,omitempty"`
+	LastModifiedTime string `json:"lastModifiedTime,omitempty"`
+}
+
+// IsModifiedSince checks whether the given item has been modified after `other` has been fetched.
+// Items without modification info are always considered to be modified.
+// A different schema version also counts as modification, because the stored value
+// may lack properties introduced by (or still contain properties removed with) the new version.
+func (me *SettingsObjectListItem) IsModifiedSince(other *SettingsObjectListItem) bool {
+	if other == nil || me.ModificationInfo == nil || other.ModificationInfo == nil {
+		return true
+	}
+	if me.SchemaVersion != other.SchemaVersion {
+		return true
+	}
+	if len(me.ModificationInfo.LastModifiedTime) == 0 {
+		return true
+	}
+	return me.ModificationInfo.LastModifiedTime != other.ModificationInfo.LastModifiedTime
 }
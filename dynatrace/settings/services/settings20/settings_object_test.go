@@ -0,0 +1,54 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settings20_test
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20"
+)
+
+func TestIsModifiedSince(t *testing.T) {
+	item := func(schemaVersion string, lastModifiedTime string) *settings20.SettingsObjectListItem {
+		return &settings20.SettingsObjectListItem{
+			ObjectID:         "object-id",
+			SchemaVersion:    schemaVersion,
+			ModificationInfo: &settings20.ModificationInfo{LastModifiedTime: lastModifiedTime},
+		}
+	}
+	tests := []struct {
+		name     string
+		listed   *settings20.SettingsObjectListItem
+		stored   *settings20.SettingsObjectListItem
+		expected bool
+	}{
+		{"unchanged", item("1.0.0", "1700000000000"), item("1.0.0", "1700000000000"), false},
+		{"modified", item("1.0.0", "1700000000001"), item("1.0.0", "1700000000000"), true},
+		{"schema version changed", item("1.1.0", "1700000000000"), item("1.0.0", "1700000000000"), true},
+		{"nothing stored", item("1.0.0", "1700000000000"), nil, true},
+		{"no modification info", &settings20.SettingsObjectListItem{ObjectID: "object-id", SchemaVersion: "1.0.0"}, item("1.0.0", "1700000000000"), true},
+		{"no last modified time", item("1.0.0", ""), item("1.0.0", ""), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.listed.IsModifiedSince(test.stored); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
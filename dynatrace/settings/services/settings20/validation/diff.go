@@ -0,0 +1,190 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diff describes how a schema has changed compared to a previous version of it
+type Diff struct {
+	SchemaID          string              `json:"schemaId"`
+	PreviousVersion   string              `json:"previousVersion"`
+	Version           string              `json:"version"`
+	AddedProperties   []string            `json:"addedProperties,omitempty"`
+	RemovedProperties []string            `json:"removedProperties,omitempty"`
+	ChangedProperties map[string][]string `json:"changedProperties,omitempty"`
+	AddedEnumValues   map[string][]string `json:"addedEnumValues,omitempty"`
+	RemovedEnumValues map[string][]string `json:"removedEnumValues,omitempty"`
+}
+
+// IsEmpty signals that apart from the version number nothing has changed
+func (me *Diff) IsEmpty() bool {
+	return len(me.AddedProperties) == 0 && len(me.RemovedProperties) == 0 && len(me.ChangedProperties) == 0 &&
+		len(me.AddedEnumValues) == 0 && len(me.RemovedEnumValues) == 0
+}
+
+// Diff compares the given schema against a previous version of it.
+// Properties of nested types are identified by their path, e.g. `severityRules[].delayInMinutes`
+func (me *Schema) Diff(previous *Schema) *Diff {
+	diff := &Diff{SchemaID: me.SchemaID, PreviousVersion: previous.Version, Version: me.Version}
+
+	properties := me.flatten()
+	previousProperties := previous.flatten()
+	for _, path := range sortedKeys(properties) {
+		previousProperty, found := previousProperties[path]
+		if !found {
+			diff.AddedProperties = append(diff.AddedProperties, path)
+			continue
+		}
+		if changes := properties[path].changes(previousProperty); len(changes) > 0 {
+			if diff.ChangedProperties == nil {
+				diff.ChangedProperties = map[string][]string{}
+			}
+			diff.ChangedProperties[path] = changes
+		}
+	}
+	for _, path := range sortedKeys(previousProperties) {
+		if _, found := properties[path]; !found {
+			diff.RemovedProperties = append(diff.RemovedProperties, path)
+		}
+	}
+
+	diff.AddedEnumValues = enumValuesMissingIn(me.Enums, previous.Enums)
+	diff.RemovedEnumValues = enumValuesMissingIn(previous.Enums, me.Enums)
+	return diff
+}
+
+// flatten collects the properties of the schema including the ones of nested types
+func (me *Schema) flatten() map[string]*Property {
+	properties := map[string]*Property{}
+	me.collect("", me.Properties, properties, map[string]bool{})
+	return properties
+}
+
+func (me *Schema) collect(prefix string, properties map[string]*Property, result map[string]*Property, visited map[string]bool) {
+	for name, property := range properties {
+		path := prefix + name
+		result[path] = property
+		typeRef := property.Type
+		if property.Items != nil {
+			typeRef = property.Items.Type
+			path = path + "[]"
+		}
+		typeName, ok := typeRef.Type()
+		if !ok || visited[typeName] {
+			continue
+		}
+		if t, found := me.Types[typeName]; found {
+			visited[typeName] = true
+			me.collect(path+".", t.Properties, result, visited)
+			delete(visited, typeName)
+		}
+	}
+}
+
+func (me *Property) typeName() string {
+	name := me.Type.Name + me.Type.Ref
+	if me.Items != nil {
+		name = fmt.Sprintf("%s of %s", name, me.Items.Type.Name+me.Items.Type.Ref)
+	}
+	return name
+}
+
+func (me *Property) constraints() string {
+	constraints := append([]*Constraint{}, me.Constraints...)
+	if me.Items != nil {
+		constraints = append(constraints, me.Items.Constraints...)
+	}
+	data, _ := json.Marshal(constraints)
+	return string(data)
+}
+
+func (me *Property) changes(previous *Property) []string {
+	changes := []string{}
+	if typeName, previousTypeName := me.typeName(), previous.typeName(); typeName != previousTypeName {
+		changes = append(changes, fmt.Sprintf("type changed from `%s` to `%s`", previousTypeName, typeName))
+	}
+	if me.constraints() != previous.constraints() {
+		changes = append(changes, "constraints changed")
+	}
+	precondition, _ := json.Marshal(me.Precondition)
+	previousPrecondition, _ := json.Marshal(previous.Precondition)
+	if string(precondition) != string(previousPrecondition) {
+		changes = append(changes, "precondition changed")
+	}
+	return changes
+}
+
+// enumValuesMissingIn returns the values of the given enums which don't exist in the other enums, grouped by enum
+func enumValuesMissingIn(enums map[string]*Enum, others map[string]*Enum) map[string][]string {
+	var result map[string][]string
+	for name, enum := range enums {
+		otherValues := map[string]bool{}
+		if other, found := others[name]; found {
+			for _, item := range other.Items {
+				otherValues[fmt.Sprintf("%v", item.Value)] = true
+			}
+		}
+		for _, item := range enum.Items {
+			value := fmt.Sprintf("%v", item.Value)
+			if !otherValues[value] {
+				if result == nil {
+					result = map[string][]string{}
+				}
+				result[name] = append(result[name], value)
+			}
+		}
+	}
+	for name := range result {
+		sort.Strings(result[name])
+	}
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (me *Diff) String() string {
+	var sb strings.Builder
+	for _, property := range me.AddedProperties {
+		sb.WriteString(fmt.Sprintf("  + property %s\n", property))
+	}
+	for _, property := range me.RemovedProperties {
+		sb.WriteString(fmt.Sprintf("  - property %s\n", property))
+	}
+	for _, property := range sortedKeys(me.ChangedProperties) {
+		sb.WriteString(fmt.Sprintf("  ~ property %s: %s\n", property, strings.Join(me.ChangedProperties[property], ", ")))
+	}
+	for _, enum := range sortedKeys(me.AddedEnumValues) {
+		sb.WriteString(fmt.Sprintf("  + enum %s: %s\n", enum, strings.Join(me.AddedEnumValues[enum], ", ")))
+	}
+	for _, enum := range sortedKeys(me.RemovedEnumValues) {
+		sb.WriteString(fmt.Sprintf("  - enum %s: %s\n", enum, strings.Join(me.RemovedEnumValues[enum], ", ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package validation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+const previousSchema = `{
+	"schemaId": "builtin:sample",
+	"version": "1.0",
+	"properties": {
+		"name": {"type": "text"},
+		"mode": {"type": {"$ref": "#/enums/Mode"}},
+		"threshold": {"type": "integer", "constraints": [{"type": "RANGE", "minimum": 0, "maximum": 10}]},
+		"legacy": {"type": "boolean"},
+		"rules": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}}
+	},
+	"types": {
+		"Rule": {"properties": {
+			"delay": {"type": "integer"},
+			"fallback": {"type": "text", "precondition": {"type": "EQUALS", "property": "delay", "expectedValue": 0}},
+			"children": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}}
+		}}
+	},
+	"enums": {"Mode": {"items": [{"value": "A"}, {"value": "B"}]}}
+}`
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		expected validation.Diff
+	}{
+		{
+			name:     "unchanged",
+			schema:   previousSchema,
+			expected: validation.Diff{SchemaID: "builtin:sample", PreviousVersion: "1.0", Version: "1.0"},
+		},
+		{
+			name: "changed",
+			schema: `{
+				"schemaId": "builtin:sample",
+				"version": "1.1",
+				"properties": {
+					"name": {"type": "text", "constraints": [{"type": "LENGTH", "maxLength": 500}]},
+					"mode": {"type": {"$ref": "#/enums/Mode"}},
+					"threshold": {"type": "float", "constraints": [{"type": "RANGE", "minimum": 0, "maximum": 10}]},
+					"description": {"type": "text"},
+					"rules": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}}
+				},
+				"types": {
+					"Rule": {"properties": {
+						"delay": {"type": "integer"},
+						"fallback": {"type": "text", "precondition": {"type": "EQUALS", "property": "delay", "expectedValue": 5}},
+						"children": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}},
+						"enabled": {"type": "boolean"}
+					}}
+				},
+				"enums": {"Mode": {"items": [{"value": "A"}, {"value": "C"}]}}
+			}`,
+			expected: validation.Diff{
+				SchemaID:          "builtin:sample",
+				PreviousVersion:   "1.0",
+				Version:           "1.1",
+				AddedProperties:   []string{"description", "rules[].enabled"},
+				RemovedProperties: []string{"legacy"},
+				ChangedProperties: map[string][]string{
+					"name":             {"constraints changed"},
+					"rules[].fallback": {"precondition changed"},
+					"threshold":        {"type changed from `integer` to `float`"},
+				},
+				AddedEnumValues:   map[string][]string{"Mode": {"C"}},
+				RemovedEnumValues: map[string][]string{"Mode": {"B"}},
+			},
+		},
+	}
+	previous, err := validation.Parse([]byte(previousSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sch, err := validation.Parse([]byte(test.schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := sch.Diff(previous)
+			if !reflect.DeepEqual(*diff, test.expected) {
+				t.Errorf("expected\n%+v\ngot\n%+v", test.expected, *diff)
+			}
+			if empty := len(test.expected.AddedProperties) == 0 && len(test.expected.RemovedProperties) == 0 &&
+				len(test.expected.ChangedProperties) == 0; diff.IsEmpty() != empty {
+				t.Errorf("expected IsEmpty() to be %v", empty)
+			}
+		})
+	}
+}
+
+func TestDiffString(t *testing.T) {
+	diff := &validation.Diff{
+		AddedProperties:   []string{"description"},
+		RemovedProperties: []string{"legacy"},
+		ChangedProperties: map[string][]string{"threshold": {"type changed from `integer` to `float`", "constraints changed"}},
+		AddedEnumValues:   map[string][]string{"Mode": {"C", "D"}},
+		RemovedEnumValues: map[string][]string{"Mode": {"B"}},
+	}
+	expected := "  + property description\n" +
+		"  - property legacy\n" +
+		"  ~ property threshold: type changed from `integer` to `float`, constraints changed\n" +
+		"  + enum Mode: C, D\n" +
+		"  - enum Mode: B\n"
+	if actual := diff.String(); actual != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, actual)
+	}
+}
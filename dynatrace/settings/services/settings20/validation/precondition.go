@@ -0,0 +1,81 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Evaluate checks whether the precondition is satisfied by the given object.
+// Properties whose precondition isn't satisfied are hidden and therefore not getting validated
+func (me *Precondition) Evaluate(object map[string]any) bool {
+	if me == nil {
+		return true
+	}
+	value := object[me.Property]
+	switch me.Type {
+	case "EQUALS":
+		return equals(value, me.ExpectedValue)
+	case "IN":
+		for _, expectedValue := range me.ExpectedValues {
+			if equals(value, expectedValue) {
+				return true
+			}
+		}
+		return false
+	case "NULL":
+		return value == nil
+	case "NOT":
+		return !me.Precondition.Evaluate(object)
+	case "AND":
+		for _, precondition := range me.Preconditions {
+			if !precondition.Evaluate(object) {
+				return false
+			}
+		}
+		return true
+	case "OR":
+		for _, precondition := range me.Preconditions {
+			if precondition.Evaluate(object) {
+				return true
+			}
+		}
+		return false
+	case "REGEX_MATCH":
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		re, err := regexp.Compile(me.Pattern)
+		if err != nil {
+			// patterns Go is unable to deal with don't hide anything
+			return true
+		}
+		return re.MatchString(s)
+	}
+	// unknown kinds of preconditions are considered to be satisfied
+	return true
+}
+
+func equals(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
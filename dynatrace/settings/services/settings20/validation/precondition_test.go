@@ -0,0 +1,64 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+func TestPreconditionEvaluate(t *testing.T) {
+	tests := []struct {
+		name         string
+		precondition string
+		object       map[string]any
+		expected     bool
+	}{
+		{"none", `null`, map[string]any{}, true},
+		{"equals", `{"type": "EQUALS", "property": "mode", "expectedValue": "A"}`, map[string]any{"mode": "A"}, true},
+		{"equals mismatch", `{"type": "EQUALS", "property": "mode", "expectedValue": "A"}`, map[string]any{"mode": "B"}, false},
+		{"equals boolean", `{"type": "EQUALS", "property": "enabled", "expectedValue": true}`, map[string]any{"enabled": true}, true},
+		{"equals missing", `{"type": "EQUALS", "property": "mode", "expectedValue": "A"}`, map[string]any{}, false},
+		{"in", `{"type": "IN", "property": "mode", "expectedValues": ["A", "B"]}`, map[string]any{"mode": "B"}, true},
+		{"in mismatch", `{"type": "IN", "property": "mode", "expectedValues": ["A", "B"]}`, map[string]any{"mode": "C"}, false},
+		{"null", `{"type": "NULL", "property": "mode"}`, map[string]any{}, true},
+		{"null mismatch", `{"type": "NULL", "property": "mode"}`, map[string]any{"mode": "A"}, false},
+		{"not", `{"type": "NOT", "precondition": {"type": "NULL", "property": "mode"}}`, map[string]any{"mode": "A"}, true},
+		{"and", `{"type": "AND", "preconditions": [{"type": "EQUALS", "property": "a", "expectedValue": 1}, {"type": "EQUALS", "property": "b", "expectedValue": 2}]}`, map[string]any{"a": float64(1), "b": float64(2)}, true},
+		{"and mismatch", `{"type": "AND", "preconditions": [{"type": "EQUALS", "property": "a", "expectedValue": 1}, {"type": "EQUALS", "property": "b", "expectedValue": 2}]}`, map[string]any{"a": float64(1), "b": float64(3)}, false},
+		{"or", `{"type": "OR", "preconditions": [{"type": "EQUALS", "property": "a", "expectedValue": 1}, {"type": "EQUALS", "property": "b", "expectedValue": 2}]}`, map[string]any{"a": float64(0), "b": float64(2)}, true},
+		{"or mismatch", `{"type": "OR", "preconditions": [{"type": "EQUALS", "property": "a", "expectedValue": 1}, {"type": "EQUALS", "property": "b", "expectedValue": 2}]}`, map[string]any{}, false},
+		{"regex", `{"type": "REGEX_MATCH", "property": "name", "pattern": "^team-"}`, map[string]any{"name": "team-a"}, true},
+		{"regex mismatch", `{"type": "REGEX_MATCH", "property": "name", "pattern": "^team-"}`, map[string]any{"name": "other"}, false},
+		{"regex unsupported by go", `{"type": "REGEX_MATCH", "property": "name", "pattern": "^(?<=a)b"}`, map[string]any{"name": "other"}, true},
+		{"unknown type", `{"type": "SOMETHING_NEW", "property": "name"}`, map[string]any{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var precondition *validation.Precondition
+			if err := json.Unmarshal([]byte(test.precondition), &precondition); err != nil {
+				t.Fatal(err)
+			}
+			if actual := precondition.Evaluate(test.object); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+)
+
+// ENABLED turns on the client side validation of settings against the bundled schemas.
+// It is opt-in, because the bundled schemas may be outdated compared to the ones of the Dynatrace environment the provider is talking to
+var ENABLED = os.Getenv("DYNATRACE_SCHEMA_VALIDATION") == "true"
+
+// Schema is the part of a Settings 2.0 `schema.json` which is relevant for validating settings objects
+type Schema struct {
+	SchemaID      string               `json:"schemaId"`
+	Version       string               `json:"version"`
+	AllowedScopes []string             `json:"allowedScopes"`
+	MultiObject   bool                 `json:"multiObject"`
+	Ordered       bool                 `json:"ordered"`
+	Properties    map[string]*Property `json:"properties"`
+	Types         map[string]*Type     `json:"types"`
+	Enums         map[string]*Enum     `json:"enums"`
+	Constraints   []*Constraint        `json:"constraints"`
+}
+
+type Type struct {
+	Properties  map[string]*Property `json:"properties"`
+	Constraints []*Constraint        `json:"constraints"`
+}
+
+type Enum struct {
+	Items []*EnumItem `json:"items"`
+}
+
+type EnumItem struct {
+	Value any `json:"value"`
+}
+
+type Property struct {
+	Type         TypeRef       `json:"type"`
+	MinObjects   *int          `json:"minObjects"`
+	MaxObjects   *int          `json:"maxObjects"`
+	Constraints  []*Constraint `json:"constraints"`
+	Items        *Item         `json:"items"`
+	Precondition *Precondition `json:"precondition"`
+}
+
+type Item struct {
+	Type        TypeRef       `json:"type"`
+	Constraints []*Constraint `json:"constraints"`
+}
+
+// TypeRef is either the name of a primitive type (`text`, `integer`, `set`, ...)
+// or a reference to one of the `types` or `enums` of the schema
+type TypeRef struct {
+	Name string
+	Ref  string
+}
+
+func (me *TypeRef) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &me.Name); err == nil {
+		return nil
+	}
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	me.Ref = ref.Ref
+	return nil
+}
+
+// Type returns the name of the complex type referenced via `#/types/<name>`
+func (me TypeRef) Type() (string, bool) {
+	return strings.CutPrefix(me.Ref, "#/types/")
+}
+
+// Enum returns the name of the enum referenced via `#/enums/<name>`
+func (me TypeRef) Enum() (string, bool) {
+	return strings.CutPrefix(me.Ref, "#/enums/")
+}
+
+type Constraint struct {
+	Type                 string   `json:"type"`
+	CustomMessage        string   `json:"customMessage"`
+	MinLength            *int     `json:"minLength"`
+	MaxLength            *int     `json:"maxLength"`
+	Minimum              *float64 `json:"minimum"`
+	Maximum              *float64 `json:"maximum"`
+	Pattern              string   `json:"pattern"`
+	UniqueProperties     []string `json:"uniqueProperties"`
+	Properties           []string `json:"properties"`
+	MinimumPropertyCount *int     `json:"minimumPropertyCount"`
+	MaximumPropertyCount *int     `json:"maximumPropertyCount"`
+}
+
+type Precondition struct {
+	Type           string          `json:"type"`
+	Property       string          `json:"property"`
+	ExpectedValue  any             `json:"expectedValue"`
+	ExpectedValues []any           `json:"expectedValues"`
+	Pattern        string          `json:"pattern"`
+	Precondition   *Precondition   `json:"precondition"`
+	Preconditions  []*Precondition `json:"preconditions"`
+}
+
+var schemas = map[string]*Schema{}
+var schemasLock sync.Mutex
+
+// Load returns the bundled schema with the given ID.
+// The result is `nil` in case no such schema has been bundled with the provider
+func Load(schemaID string) (*Schema, error) {
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	if schema, found := schemas[schemaID]; found {
+		return schema, nil
+	}
+	data, found := api.BundledSchema(schemaID)
+	if !found {
+		schemas[schemaID] = nil
+		return nil, nil
+	}
+	schema, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the bundled schema `%s`: %s", schemaID, err.Error())
+	}
+	schemas[schemaID] = schema
+	return schema, nil
+}
+
+// Parse reads a schema in the format delivered by `/api/v2/settings/schemas/{schemaId}`
+func Parse(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
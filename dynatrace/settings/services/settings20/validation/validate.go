@@ -0,0 +1,352 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Path addresses a value within a settings object.
+// Its elements are either property names or indices of list elements
+type Path []any
+
+func (me Path) Append(elem any) Path {
+	return append(append(Path{}, me...), elem)
+}
+
+func (me Path) String() string {
+	var sb strings.Builder
+	for _, elem := range me {
+		switch e := elem.(type) {
+		case int:
+			sb.WriteString("[" + strconv.Itoa(e) + "]")
+		default:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(fmt.Sprintf("%v", e))
+		}
+	}
+	return sb.String()
+}
+
+// Violation describes a value which doesn't comply with the schema
+type Violation struct {
+	Path    Path
+	Message string
+}
+
+func (me *Violation) Error() string {
+	if len(me.Path) == 0 {
+		return me.Message
+	}
+	return fmt.Sprintf("%s: %s", me.Path.String(), me.Message)
+}
+
+// Validate checks the JSON representation of the given settings against the bundled schema with the given ID.
+// No violations are getting reported in case no such schema has been bundled.
+func Validate(schemaID string, v any) ([]*Violation, error) {
+	schema, err := Load(schemaID)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return schema.Validate(value), nil
+}
+
+// Validate checks the given value of a settings object against the schema
+func (me *Schema) Validate(value map[string]any) []*Violation {
+	validator := &validator{schema: me}
+	validator.object(Path{}, me.Properties, me.Constraints, value)
+	return validator.violations
+}
+
+type validator struct {
+	schema     *Schema
+	violations []*Violation
+}
+
+func (me *validator) report(path Path, constraint *Constraint, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if constraint != nil && len(constraint.CustomMessage) > 0 {
+		message = constraint.CustomMessage
+	}
+	me.violations = append(me.violations, &Violation{Path: path, Message: message})
+}
+
+func (me *validator) object(path Path, properties map[string]*Property, constraints []*Constraint, object map[string]any) {
+	names := []string{}
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := properties[name]
+		if !property.Precondition.Evaluate(object) {
+			continue
+		}
+		// missing properties are getting filled in with defaults on the server side
+		value, found := object[name]
+		if !found || value == nil {
+			continue
+		}
+		me.value(path.Append(name), property.Type, property.Items, property.Constraints, value)
+		if elems, ok := value.([]any); ok {
+			if property.MinObjects != nil && len(elems) < *property.MinObjects {
+				me.report(path.Append(name), nil, "at least %d elements are required", *property.MinObjects)
+			}
+			if property.MaxObjects != nil && len(elems) > *property.MaxObjects {
+				me.report(path.Append(name), nil, "at most %d elements are allowed", *property.MaxObjects)
+			}
+		}
+	}
+
+	for _, constraint := range constraints {
+		me.complexConstraint(path, constraint, object)
+	}
+}
+
+func (me *validator) value(path Path, typeRef TypeRef, items *Item, constraints []*Constraint, value any) {
+	if typeName, ok := typeRef.Type(); ok {
+		object, ok := value.(map[string]any)
+		if !ok {
+			me.report(path, nil, "expected an object")
+			return
+		}
+		if t, found := me.schema.Types[typeName]; found {
+			me.object(path, t.Properties, t.Constraints, object)
+		}
+		return
+	}
+	if enumName, ok := typeRef.Enum(); ok {
+		if enum, found := me.schema.Enums[enumName]; found {
+			allowed := []string{}
+			for _, item := range enum.Items {
+				if equals(value, item.Value) {
+					return
+				}
+				allowed = append(allowed, fmt.Sprintf("%v", item.Value))
+			}
+			me.report(path, nil, "`%v` is not a valid value. Expected one of: %s", value, strings.Join(allowed, ", "))
+		}
+		return
+	}
+
+	switch typeRef.Name {
+	case "text", "secret", "setting", "zoned_date_time", "local_date_time", "local_date", "local_time", "time_zone":
+		if _, ok := value.(string); !ok {
+			me.report(path, nil, "expected a string")
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			me.report(path, nil, "expected a boolean")
+			return
+		}
+	case "integer":
+		if f, ok := value.(float64); !ok || f != math.Trunc(f) {
+			me.report(path, nil, "expected an integer")
+			return
+		}
+	case "float":
+		if _, ok := value.(float64); !ok {
+			me.report(path, nil, "expected a number")
+			return
+		}
+	case "set", "list":
+		elems, ok := value.([]any)
+		if !ok {
+			me.report(path, nil, "expected a list")
+			return
+		}
+		if items != nil {
+			for idx, elem := range elems {
+				if elem != nil {
+					me.value(path.Append(idx), items.Type, nil, items.Constraints, elem)
+				}
+			}
+		}
+	}
+
+	for _, constraint := range constraints {
+		me.constraint(path, constraint, value)
+	}
+}
+
+func (me *validator) constraint(path Path, constraint *Constraint, value any) {
+	switch constraint.Type {
+	case "LENGTH":
+		s, ok := value.(string)
+		if !ok {
+			return
+		}
+		length := utf8.RuneCountInString(s)
+		if constraint.MinLength != nil && length < *constraint.MinLength {
+			me.report(path, constraint, "must be at least %d characters long", *constraint.MinLength)
+		}
+		if constraint.MaxLength != nil && length > *constraint.MaxLength {
+			me.report(path, constraint, "must not be longer than %d characters", *constraint.MaxLength)
+		}
+	case "RANGE":
+		f, ok := value.(float64)
+		if !ok {
+			return
+		}
+		if constraint.Minimum != nil && f < *constraint.Minimum {
+			me.report(path, constraint, "must not be less than %v", *constraint.Minimum)
+		}
+		if constraint.Maximum != nil && f > *constraint.Maximum {
+			me.report(path, constraint, "must not be greater than %v", *constraint.Maximum)
+		}
+	case "PATTERN":
+		s, ok := value.(string)
+		if !ok {
+			return
+		}
+		// schemas are written for Java regular expressions - the ones Go can't compile are left to the server side validation
+		if re := compile(constraint.Pattern); re != nil && !re.MatchString(s) {
+			me.report(path, constraint, "`%s` doesn't match the pattern `%s`", s, constraint.Pattern)
+		}
+	case "NOT_BLANK":
+		if s, ok := value.(string); ok && len(strings.TrimSpace(s)) == 0 {
+			me.report(path, constraint, "must not be blank")
+		}
+	case "NOT_EMPTY":
+		switch v := value.(type) {
+		case string:
+			if len(v) == 0 {
+				me.report(path, constraint, "must not be empty")
+			}
+		case []any:
+			if len(v) == 0 {
+				me.report(path, constraint, "must not be empty")
+			}
+		}
+	case "TRIMMED":
+		if s, ok := value.(string); ok && s != strings.TrimSpace(s) {
+			me.report(path, constraint, "must not contain leading or trailing whitespaces")
+		}
+	case "NO_WHITESPACE":
+		if s, ok := value.(string); ok && strings.IndexFunc(s, unicode.IsSpace) != -1 {
+			me.report(path, constraint, "must not contain whitespaces")
+		}
+	case "UNIQUE":
+		elems, ok := value.([]any)
+		if !ok {
+			return
+		}
+		seen := map[string]int{}
+		for idx, elem := range elems {
+			key := uniqueKey(elem, constraint.UniqueProperties)
+			if prev, found := seen[key]; found {
+				if len(constraint.UniqueProperties) > 0 {
+					me.report(path.Append(idx), constraint, "the values of %s must be unique, but are identical to the ones of element %d", strings.Join(constraint.UniqueProperties, ", "), prev)
+				} else {
+					me.report(path.Append(idx), constraint, "must be unique, but is identical to element %d", prev)
+				}
+				continue
+			}
+			seen[key] = idx
+		}
+	}
+}
+
+// complexConstraint validates constraints of objects which are spanning over several properties
+func (me *validator) complexConstraint(path Path, constraint *Constraint, object map[string]any) {
+	switch constraint.Type {
+	case "LESS_THAN", "LESS_THAN_OR_EQUAL", "GREATER_THAN", "GREATER_THAN_OR_EQUAL":
+		if len(constraint.Properties) != 2 {
+			return
+		}
+		a, aok := object[constraint.Properties[0]].(float64)
+		b, bok := object[constraint.Properties[1]].(float64)
+		if !aok || !bok {
+			return
+		}
+		var valid bool
+		var operator string
+		switch constraint.Type {
+		case "LESS_THAN":
+			valid, operator = a < b, "less than"
+		case "LESS_THAN_OR_EQUAL":
+			valid, operator = a <= b, "less than or equal to"
+		case "GREATER_THAN":
+			valid, operator = a > b, "greater than"
+		case "GREATER_THAN_OR_EQUAL":
+			valid, operator = a >= b, "greater than or equal to"
+		}
+		if !valid {
+			me.report(path.Append(constraint.Properties[0]), constraint, "`%s` must be %s `%s`", constraint.Properties[0], operator, constraint.Properties[1])
+		}
+	case "PROPERTY_COUNT_RANGE":
+		count := 0
+		for _, property := range constraint.Properties {
+			if object[property] != nil {
+				count++
+			}
+		}
+		if constraint.MinimumPropertyCount != nil && count < *constraint.MinimumPropertyCount {
+			me.report(path, constraint, "at least %d of %s need to be specified", *constraint.MinimumPropertyCount, strings.Join(constraint.Properties, ", "))
+		}
+		if constraint.MaximumPropertyCount != nil && count > *constraint.MaximumPropertyCount {
+			me.report(path, constraint, "at most %d of %s may be specified", *constraint.MaximumPropertyCount, strings.Join(constraint.Properties, ", "))
+		}
+	}
+}
+
+func uniqueKey(elem any, properties []string) string {
+	if object, ok := elem.(map[string]any); ok && len(properties) > 0 {
+		values := []any{}
+		for _, property := range properties {
+			values = append(values, object[property])
+		}
+		data, _ := json.Marshal(values)
+		return string(data)
+	}
+	data, _ := json.Marshal(elem)
+	return string(data)
+}
+
+var patterns = map[string]*regexp.Regexp{}
+
+func compile(pattern string) *regexp.Regexp {
+	schemasLock.Lock()
+	defer schemasLock.Unlock()
+	if re, found := patterns[pattern]; found {
+		return re
+	}
+	re, _ := regexp.Compile(pattern)
+	patterns[pattern] = re
+	return re
+}
@@ -0,0 +1,109 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+const testSchema = `{
+	"schemaId": "builtin:test",
+	"properties": {
+		"name": { "type": "text", "constraints": [
+			{ "type": "LENGTH", "minLength": 1, "maxLength": 5 },
+			{ "type": "TRIMMED" }
+		] },
+		"code": { "type": "text", "constraints": [ { "type": "PATTERN", "pattern": "^[A-Z]+$", "customMessage": "only upper case letters" } ] },
+		"port": { "type": "integer", "constraints": [ { "type": "RANGE", "minimum": 1, "maximum": 65535 } ] },
+		"kind": { "type": { "$ref": "#/enums/Kind" } },
+		"tags": { "type": "set", "items": { "type": "text", "constraints": [ { "type": "NO_WHITESPACE" } ] }, "constraints": [ { "type": "UNIQUE" } ], "maxObjects": 2 },
+		"rules": { "type": "list", "items": { "type": { "$ref": "#/types/Rule" } } },
+		"low": { "type": "integer" },
+		"high": { "type": "integer" }
+	},
+	"constraints": [
+		{ "type": "LESS_THAN", "properties": [ "low", "high" ] }
+	],
+	"enums": {
+		"Kind": { "items": [ { "value": "A" }, { "value": "B" } ] }
+	},
+	"types": {
+		"Rule": {
+			"properties": {
+				"mode": { "type": { "$ref": "#/enums/Kind" } },
+				"pattern": { "type": "text", "constraints": [ { "type": "NOT_BLANK" } ], "precondition": { "type": "EQUALS", "property": "mode", "expectedValue": "B" } }
+			},
+			"constraints": [
+				{ "type": "PROPERTY_COUNT_RANGE", "properties": [ "mode", "pattern" ], "minimumPropertyCount": 1 }
+			]
+		}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := validation.Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		value    map[string]any
+		expected []string
+	}{
+		{"valid", map[string]any{"name": "abc", "code": "AB", "port": float64(443), "kind": "A", "tags": []any{"a", "b"}, "low": float64(1), "high": float64(2)}, nil},
+		{"missing properties", map[string]any{}, nil},
+		{"too short", map[string]any{"name": ""}, []string{"name: must be at least 1 characters long"}},
+		{"too long", map[string]any{"name": "abcdef"}, []string{"name: must not be longer than 5 characters"}},
+		{"not trimmed", map[string]any{"name": " ab"}, []string{"name: must not contain leading or trailing whitespaces"}},
+		{"custom message", map[string]any{"code": "ab"}, []string{"code: only upper case letters"}},
+		{"below range", map[string]any{"port": float64(0)}, []string{"port: must not be less than 1"}},
+		{"above range", map[string]any{"port": float64(65536)}, []string{"port: must not be greater than 65535"}},
+		{"no integer", map[string]any{"port": float64(1.5)}, []string{"port: expected an integer"}},
+		{"wrong type", map[string]any{"name": float64(1)}, []string{"name: expected a string"}},
+		{"unknown enum value", map[string]any{"kind": "C"}, []string{"kind: `C` is not a valid value. Expected one of: A, B"}},
+		{"item constraint", map[string]any{"tags": []any{"a b"}}, []string{"tags[0]: must not contain whitespaces"}},
+		{"not unique", map[string]any{"tags": []any{"a", "a"}}, []string{"tags[1]: must be unique, but is identical to element 0"}},
+		{"too many elements", map[string]any{"tags": []any{"a", "b", "c"}}, []string{"tags: at most 2 elements are allowed"}},
+		{"comparison", map[string]any{"low": float64(2), "high": float64(2)}, []string{"low: `low` must be less than `high`"}},
+		{"nested object", map[string]any{"rules": []any{map[string]any{"mode": "A"}, map[string]any{"mode": "C"}}}, []string{"rules[1].mode: `C` is not a valid value. Expected one of: A, B"}},
+		{"property count", map[string]any{"rules": []any{map[string]any{}}}, []string{"rules[0]: at least 1 of mode, pattern need to be specified"}},
+		{"precondition satisfied", map[string]any{"rules": []any{map[string]any{"mode": "B", "pattern": " "}}}, []string{"rules[0].pattern: must not be blank"}},
+		{"precondition not satisfied", map[string]any{"rules": []any{map[string]any{"mode": "A", "pattern": " "}}}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations := schema.Validate(test.value)
+			actual := []string{}
+			for _, violation := range violations {
+				actual = append(actual, violation.Error())
+			}
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected violations %v, got %v", test.expected, actual)
+			}
+			for idx := range actual {
+				if actual[idx] != test.expected[idx] {
+					t.Errorf("expected violations %v, got %v", test.expected, actual)
+					break
+				}
+			}
+		})
+	}
+}
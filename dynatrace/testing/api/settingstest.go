@@ -28,6 +28,7 @@ import (
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/assert"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/fake"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -66,6 +67,7 @@ type SettingsTest[V settings.Settings] struct {
 
 func (st SettingsTest[V]) Run(createService func(*settings.Credentials) settings.CRUDService[V]) {
 	st.T.Helper()
+	fake.Setup(st.T, ".")
 	envURL := os.Getenv("DYNATRACE_ENV_URL")
 	apiToken := os.Getenv("DYNATRACE_API_TOKEN")
 	if envURL == "" || apiToken == "" {
@@ -211,6 +213,7 @@ func TestAcc(t *testing.T, opts ...TestAccOptions) {
 		t.Skip("TF_ACC has not been set for acceptance tests")
 		return
 	}
+	fake.Setup(t, ".")
 	if v := os.Getenv("DYNATRACE_ENV_URL"); v == "" {
 		t.Skip("DYNATRACE_ENV_URL has not been set for acceptance tests")
 		return
@@ -0,0 +1,274 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// profile describes how the REST endpoints below a specific path deviate from the
+// conventions of the Configuration API (v1)
+type profile struct {
+	// ListKey is the property of the list response containing the records
+	ListKey string
+	// IDField is the property containing the ID of a record
+	IDField string
+	// IDPrefix produces a prefix for IDs of newly created records
+	IDPrefix func(record map[string]any) string
+	// CreateStatus is the status code of successful POST requests
+	CreateStatus int
+	// UpdateStatus is the status code of successful PUT requests
+	UpdateStatus int
+	// PutCreates signals that PUT requests for unknown IDs are creating the record with a response code 201
+	PutCreates bool
+	// Location signals that the ID of a created record is only getting returned via the `Location` header
+	Location bool
+}
+
+var defaultProfile = profile{ListKey: "values", IDField: "id", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusNoContent}
+
+var profiles = map[string]profile{
+	"/api/config/v1/dashboards": {ListKey: "dashboards", IDField: "id", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusNoContent},
+	"/api/v1/synthetic/monitors": {ListKey: "monitors", IDField: "entityId", CreateStatus: http.StatusOK, UpdateStatus: http.StatusNoContent, IDPrefix: func(record map[string]any) string {
+		if record["type"] == "BROWSER" {
+			return "SYNTHETIC_TEST-"
+		}
+		return "HTTP_CHECK-"
+	}},
+	"/api/v1/synthetic/locations": {ListKey: "locations", IDField: "entityId", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusNoContent, IDPrefix: func(map[string]any) string {
+		return "SYNTHETIC_LOCATION-"
+	}},
+	"/api/v2/credentials":  {ListKey: "credentials", IDField: "id", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusNoContent},
+	"/api/v2/networkZones": {ListKey: "networkZones", IDField: "id", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusNoContent, PutCreates: true},
+	"/api/v2/slo":          {ListKey: "slo", IDField: "id", CreateStatus: http.StatusCreated, UpdateStatus: http.StatusOK, Location: true},
+}
+
+// pagingParameters are query parameters which don't act as filters when listing records
+var pagingParameters = map[string]bool{
+	"pageSize": true, "pageIdx": true, "nextPageKey": true, "sort": true, "timeFrame": true, "demo": true,
+	"evaluate": true, "fields": true, "from": true, "to": true, "sloSelector": true,
+}
+
+var sloSelectorRegex = regexp.MustCompile(`id\("([^"]*)"\)`)
+
+// collection holds the records stored below a specific path
+type collection struct {
+	profile profile
+	records map[string]map[string]any
+	order   []string
+}
+
+func (me *Tenant) collection(path string) *collection {
+	if c, found := me.collections[path]; found {
+		return c
+	}
+	p, found := profiles[path]
+	if !found {
+		p = defaultProfile
+	}
+	c := &collection{profile: p, records: map[string]map[string]any{}}
+	me.collections[path] = c
+	return c
+}
+
+func (me *collection) store(id string, record map[string]any) {
+	if _, found := me.records[id]; !found {
+		me.order = append(me.order, id)
+	}
+	record[me.profile.IDField] = id
+	me.records[id] = record
+}
+
+func (me *collection) remove(id string) {
+	delete(me.records, id)
+	order := []string{}
+	for _, oid := range me.order {
+		if oid != id {
+			order = append(order, oid)
+		}
+	}
+	me.order = order
+}
+
+func recordName(record map[string]any) string {
+	if name, ok := record["name"].(string); ok {
+		return name
+	}
+	if metadata, ok := record["dashboardMetadata"].(map[string]any); ok {
+		if name, ok := metadata["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// serveCollections handles any REST endpoint following the conventions of the Configuration API (v1).
+// The path of the request is either the collection itself or the collection followed by the ID of a record
+func (me *Tenant) serveCollections(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if strings.HasSuffix(path, "/validator") {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		if _, err := readRecord(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodPost {
+		if _, found := me.collections[path]; found || r.Method == http.MethodPost || !me.isRecordPath(path) {
+			me.serveCollection(w, r, path)
+			return
+		}
+	}
+	idx := strings.LastIndex(path, "/")
+	me.serveRecord(w, r, path[:idx], path[idx+1:])
+}
+
+// isRecordPath checks whether the given path addresses a record within an already known collection
+func (me *Tenant) isRecordPath(path string) bool {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return false
+	}
+	_, found := me.collections[path[:idx]]
+	return found
+}
+
+func readRecord(r *http.Request) (map[string]any, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil || record == nil {
+		return nil, fmt.Errorf("Could not map JSON at '' near line 1")
+	}
+	return record, nil
+}
+
+func (me *Tenant) serveCollection(w http.ResponseWriter, r *http.Request, path string) {
+	c := me.collection(path)
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		ids := map[string]bool{}
+		if m := sloSelectorRegex.FindAllStringSubmatch(query.Get("sloSelector"), -1); len(m) > 0 {
+			for _, match := range m {
+				ids[match[1]] = true
+			}
+		}
+		records := []map[string]any{}
+		for _, id := range c.order {
+			record := c.records[id]
+			if len(ids) > 0 && !ids[id] {
+				continue
+			}
+			if !matchesFilters(record, query) {
+				continue
+			}
+			records = append(records, record)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{c.profile.ListKey: records, "totalCount": len(records)})
+	case http.MethodPost:
+		record, err := readRecord(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id := uuid.NewString()
+		if c.profile.IDPrefix != nil {
+			id = c.profile.IDPrefix(record) + strings.ToUpper(strings.ReplaceAll(id, "-", "")[:16])
+		}
+		c.store(id, record)
+		if c.profile.Location {
+			w.Header().Set("Location", r.URL.Path+"/"+id)
+			w.WriteHeader(c.profile.CreateStatus)
+			return
+		}
+		writeJSON(w, c.profile.CreateStatus, map[string]any{c.profile.IDField: id, "name": recordName(record)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func matchesFilters(record map[string]any, query map[string][]string) bool {
+	keys := []string{}
+	for key := range query {
+		if !pagingParameters[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if value, found := record[key]; !found || fmt.Sprintf("%v", value) != query[key][0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (me *Tenant) serveRecord(w http.ResponseWriter, r *http.Request, path string, id string) {
+	c := me.collection(path)
+	record, found := c.records[id]
+	switch r.Method {
+	case http.MethodGet:
+		if !found {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("The requested configuration %s does not exist", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, record)
+	case http.MethodPut:
+		record, err := readRecord(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.store(id, record)
+		if !found && c.profile.PutCreates {
+			writeJSON(w, http.StatusCreated, map[string]any{c.profile.IDField: id, "name": recordName(record)})
+			return
+		}
+		if c.profile.UpdateStatus == http.StatusNoContent {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, c.profile.UpdateStatus, record)
+	case http.MethodDelete:
+		if !found {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("The requested configuration %s does not exist", id))
+			return
+		}
+		c.remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
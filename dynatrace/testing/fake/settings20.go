@@ -0,0 +1,486 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package fake
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schema holds the parts of a `schema.json` the fake environment enforces
+type schema struct {
+	SchemaID      string   `json:"schemaId"`
+	Version       string   `json:"version"`
+	DisplayName   string   `json:"displayName"`
+	MultiObject   bool     `json:"multiObject"`
+	Ordered       bool     `json:"ordered"`
+	MaxObjects    int      `json:"maxObjects"`
+	AllowedScopes []string `json:"allowedScopes"`
+
+	raw json.RawMessage
+}
+
+// LoadSchemas registers the Settings 2.0 schemas of all `schema.json` files within the given folder and its sub folders.
+// Objects of schemas which haven't been registered are getting accepted without any validation
+func (me *Tenant) LoadSchemas(folder string) error {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	return filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "schema.json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var s schema
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("unable to load `%s`: %s", path, err.Error())
+		}
+		if len(s.SchemaID) == 0 {
+			return nil
+		}
+		s.raw = data
+		me.schemas[s.SchemaID] = &s
+		return nil
+	})
+}
+
+type settingsObject struct {
+	ObjectID      string
+	SchemaID      string
+	SchemaVersion string
+	Scope         string
+	Value         json.RawMessage
+	Created       time.Time
+	Modified      time.Time
+}
+
+type settingsStore struct {
+	objects map[string]*settingsObject
+	// the object IDs per schema, in the order they have been positioned in
+	order map[string][]string
+	seq   int
+}
+
+func newSettingsStore() *settingsStore {
+	return &settingsStore{objects: map[string]*settingsObject{}, order: map[string][]string{}}
+}
+
+// objectIDHeader is the binary prefix of the object IDs issued by real environments (`vu9U3hXa3q0AAAAB` when encoded)
+var objectIDHeader = []byte{0xbe, 0xef, 0x54, 0xde, 0x15, 0xda, 0xde, 0xad, 0x00, 0x00, 0x00, 0x01}
+
+// newObjectID issues object IDs in the format of real environments, i.e. encoding schema, scope and a UUID
+func (me *settingsStore) newObjectID(schemaID string, scope string) string {
+	me.seq++
+	var buf bytes.Buffer
+	buf.Write(objectIDHeader)
+	for _, s := range []string{schemaID, scope, "", fmt.Sprintf("00000000-0000-0000-0000-%012d", me.seq)} {
+		binary.Write(&buf, binary.BigEndian, uint16(len(s)))
+		buf.WriteString(s)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+// position places the given object ID after `insertAfter`.
+// `nil` appends the object at the end, an empty string moves it to the first position
+func (me *settingsStore) position(schemaID string, objectID string, insertAfter *string) bool {
+	ids := []string{}
+	for _, id := range me.order[schemaID] {
+		if id != objectID {
+			ids = append(ids, id)
+		}
+	}
+	switch {
+	case insertAfter == nil:
+		ids = append(ids, objectID)
+	case len(*insertAfter) == 0:
+		ids = append([]string{objectID}, ids...)
+	default:
+		idx := -1
+		for i, id := range ids {
+			if id == *insertAfter {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return false
+		}
+		ids = append(ids[:idx+1], append([]string{objectID}, ids[idx+1:]...)...)
+	}
+	me.order[schemaID] = ids
+	return true
+}
+
+func (me *settingsStore) remove(objectID string) {
+	object, found := me.objects[objectID]
+	if !found {
+		return
+	}
+	delete(me.objects, objectID)
+	ids := []string{}
+	for _, id := range me.order[object.SchemaID] {
+		if id != objectID {
+			ids = append(ids, id)
+		}
+	}
+	me.order[object.SchemaID] = ids
+}
+
+func (me *settingsObject) fields(fields map[string]bool) map[string]any {
+	m := map[string]any{"objectId": me.ObjectID}
+	if fields["value"] {
+		m["value"] = me.Value
+	}
+	if fields["scope"] {
+		m["scope"] = me.Scope
+	}
+	if fields["schemaId"] {
+		m["schemaId"] = me.SchemaID
+	}
+	if fields["schemaVersion"] {
+		m["schemaVersion"] = me.SchemaVersion
+	}
+	if fields["modificationInfo"] {
+		m["modificationInfo"] = map[string]any{
+			"createdBy":        "fake",
+			"createdTime":      me.Created.Format(time.RFC3339Nano),
+			"lastModifiedBy":   "fake",
+			"lastModifiedTime": me.Modified.Format(time.RFC3339Nano),
+		}
+	}
+	return m
+}
+
+var allObjectFields = map[string]bool{"value": true, "scope": true, "schemaId": true, "schemaVersion": true, "modificationInfo": true}
+
+func (me *Tenant) serveSettings(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/settings/"), "/")
+	switch {
+	case path == "objects" && r.Method == http.MethodGet:
+		me.listSettingsObjects(w, r)
+	case path == "objects" && r.Method == http.MethodPost:
+		me.createSettingsObjects(w, r)
+	case strings.HasPrefix(path, "objects/"):
+		objectID, _ := url.PathUnescape(strings.TrimPrefix(path, "objects/"))
+		switch r.Method {
+		case http.MethodGet:
+			object, found := me.settings.objects[objectID]
+			if !found {
+				writeError(w, http.StatusNotFound, "Settings not found")
+				return
+			}
+			writeJSON(w, http.StatusOK, object.fields(allObjectFields))
+		case http.MethodPut:
+			me.updateSettingsObject(w, r, objectID)
+		case http.MethodDelete:
+			if _, found := me.settings.objects[objectID]; !found {
+				writeError(w, http.StatusNotFound, "Settings not found")
+				return
+			}
+			me.settings.remove(objectID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	case path == "schemas" && r.Method == http.MethodGet:
+		items := []map[string]any{}
+		for _, s := range me.schemas {
+			items = append(items, map[string]any{"schemaId": s.SchemaID, "displayName": s.DisplayName, "latestSchemaVersion": s.Version})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i]["schemaId"].(string) < items[j]["schemaId"].(string) })
+		writeJSON(w, http.StatusOK, map[string]any{"items": items, "totalCount": len(items)})
+	case strings.HasPrefix(path, "schemas/") && r.Method == http.MethodGet:
+		schemaID, _ := url.PathUnescape(strings.TrimPrefix(path, "schemas/"))
+		s, found := me.schemas[schemaID]
+		if !found {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Schema '%s' not found", schemaID))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.raw)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// pageKey is the state of a paged list request, encoded within `nextPageKey`
+type pageKey struct {
+	SchemaIDs string `json:"schemaIds"`
+	Scopes    string `json:"scopes"`
+	Fields    string `json:"fields"`
+	PageSize  int    `json:"pageSize"`
+	Offset    int    `json:"offset"`
+}
+
+func (me *Tenant) listSettingsObjects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	key := pageKey{SchemaIDs: query.Get("schemaIds"), Scopes: query.Get("scopes"), Fields: query.Get("fields"), PageSize: 100}
+	if sNextPageKey := query.Get("nextPageKey"); len(sNextPageKey) > 0 {
+		data, err := base64.RawURLEncoding.DecodeString(sNextPageKey)
+		if err == nil {
+			err = json.Unmarshal(data, &key)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid next page key")
+			return
+		}
+	} else if sPageSize := query.Get("pageSize"); len(sPageSize) > 0 {
+		pageSize, err := strconv.Atoi(sPageSize)
+		if err != nil || pageSize < 1 || pageSize > 500 {
+			writeError(w, http.StatusBadRequest, "pageSize must be between 1 and 500")
+			return
+		}
+		key.PageSize = pageSize
+	}
+
+	fields := map[string]bool{"value": true}
+	if len(key.Fields) > 0 {
+		fields = map[string]bool{}
+		for _, field := range strings.Split(key.Fields, ",") {
+			fields[strings.TrimSpace(field)] = true
+		}
+	}
+	scopes := map[string]bool{}
+	if len(key.Scopes) > 0 {
+		for _, scope := range strings.Split(key.Scopes, ",") {
+			scopes[strings.TrimSpace(scope)] = true
+		}
+	}
+	schemaIDs := []string{}
+	if len(key.SchemaIDs) > 0 {
+		schemaIDs = strings.Split(key.SchemaIDs, ",")
+	} else {
+		for schemaID := range me.settings.order {
+			schemaIDs = append(schemaIDs, schemaID)
+		}
+		sort.Strings(schemaIDs)
+	}
+
+	matches := []*settingsObject{}
+	for _, schemaID := range schemaIDs {
+		for _, objectID := range me.settings.order[strings.TrimSpace(schemaID)] {
+			object := me.settings.objects[objectID]
+			if len(scopes) > 0 && !scopes[object.Scope] {
+				continue
+			}
+			matches = append(matches, object)
+		}
+	}
+
+	items := []map[string]any{}
+	end := key.Offset + key.PageSize
+	if end > len(matches) {
+		end = len(matches)
+	}
+	for _, object := range matches[min(key.Offset, len(matches)):end] {
+		items = append(items, object.fields(fields))
+	}
+	response := map[string]any{"items": items, "totalCount": len(matches), "pageSize": key.PageSize}
+	if end < len(matches) {
+		next := key
+		next.Offset = end
+		data, _ := json.Marshal(next)
+		response["nextPageKey"] = base64.RawURLEncoding.EncodeToString(data)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+type settingsObjectCreate struct {
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Scope         string          `json:"scope"`
+	Value         json.RawMessage `json:"value"`
+	InsertAfter   *string         `json:"insertAfter"`
+}
+
+type settingsObjectResponse struct {
+	Code     int           `json:"code"`
+	ObjectID string        `json:"objectId,omitempty"`
+	Error    *errorDetails `json:"error,omitempty"`
+}
+
+func (me *Tenant) createSettingsObjects(w http.ResponseWriter, r *http.Request) {
+	var creates []settingsObjectCreate
+	data, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(data, &creates)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Could not map JSON at '' near line 1")
+		return
+	}
+	validateOnly := r.URL.Query().Get("validateOnly") == "true"
+
+	status := http.StatusOK
+	responses := []settingsObjectResponse{}
+	for _, create := range creates {
+		response := me.createSettingsObject(create, validateOnly)
+		if response.Error != nil && status == http.StatusOK {
+			status = response.Code
+		}
+		responses = append(responses, response)
+	}
+	writeJSON(w, status, responses)
+}
+
+func (me *Tenant) createSettingsObject(create settingsObjectCreate, validateOnly bool) settingsObjectResponse {
+	if len(create.Scope) == 0 {
+		create.Scope = "environment"
+	}
+	if err := me.validateSettingsObject(create.SchemaID, create.Scope, create.Value); err != nil {
+		return settingsObjectResponse{Code: err.Code, Error: err}
+	}
+	s := me.schemas[create.SchemaID]
+	// single object schemas hold at most one object per scope - creating another one replaces it
+	var existing *settingsObject
+	if s != nil && !s.MultiObject {
+		for _, objectID := range me.settings.order[create.SchemaID] {
+			if object := me.settings.objects[objectID]; object.Scope == create.Scope {
+				existing = object
+			}
+		}
+	}
+	if existing == nil && s != nil && s.MaxObjects > 0 && len(me.settings.order[create.SchemaID]) >= s.MaxObjects {
+		return settingsObjectResponse{Code: http.StatusBadRequest, Error: &errorDetails{
+			Code:                 http.StatusBadRequest,
+			Message:              "Constraints violated.",
+			ConstraintViolations: []constraintViolation{{Message: fmt.Sprintf("Schema '%s' allows at most %d objects", create.SchemaID, s.MaxObjects)}},
+		}}
+	}
+	if create.InsertAfter != nil && len(*create.InsertAfter) > 0 {
+		if _, found := me.settings.objects[*create.InsertAfter]; !found {
+			return settingsObjectResponse{Code: http.StatusNotFound, Error: &errorDetails{Code: http.StatusNotFound, Message: "Settings not found"}}
+		}
+	}
+	if validateOnly {
+		return settingsObjectResponse{Code: http.StatusOK}
+	}
+	now := time.Now()
+	if existing != nil {
+		existing.Value = create.Value
+		existing.Modified = now
+		return settingsObjectResponse{Code: http.StatusOK, ObjectID: existing.ObjectID}
+	}
+	object := &settingsObject{
+		ObjectID:      me.settings.newObjectID(create.SchemaID, create.Scope),
+		SchemaID:      create.SchemaID,
+		SchemaVersion: me.schemaVersion(create.SchemaID, create.SchemaVersion),
+		Scope:         create.Scope,
+		Value:         create.Value,
+		Created:       now,
+		Modified:      now,
+	}
+	me.settings.objects[object.ObjectID] = object
+	me.settings.position(object.SchemaID, object.ObjectID, create.InsertAfter)
+	return settingsObjectResponse{Code: http.StatusOK, ObjectID: object.ObjectID}
+}
+
+type settingsObjectUpdate struct {
+	SchemaVersion string          `json:"schemaVersion"`
+	Value         json.RawMessage `json:"value"`
+	InsertAfter   *string         `json:"insertAfter"`
+}
+
+func (me *Tenant) updateSettingsObject(w http.ResponseWriter, r *http.Request, objectID string) {
+	object, found := me.settings.objects[objectID]
+	if !found {
+		writeError(w, http.StatusNotFound, "Settings not found")
+		return
+	}
+	var update settingsObjectUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "Could not map JSON at '' near line 1")
+		return
+	}
+	if err := me.validateSettingsObject(object.SchemaID, object.Scope, update.Value); err != nil {
+		writeJSON(w, err.Code, errorEnvelope{Error: err})
+		return
+	}
+	if r.URL.Query().Get("validateOnly") == "true" {
+		writeJSON(w, http.StatusOK, settingsObjectResponse{Code: http.StatusOK, ObjectID: objectID})
+		return
+	}
+	if update.InsertAfter != nil {
+		if !me.settings.position(object.SchemaID, objectID, update.InsertAfter) {
+			writeError(w, http.StatusNotFound, "Settings not found")
+			return
+		}
+	}
+	object.Value = update.Value
+	object.SchemaVersion = me.schemaVersion(object.SchemaID, update.SchemaVersion)
+	object.Modified = time.Now()
+	writeJSON(w, http.StatusOK, settingsObjectResponse{Code: http.StatusOK, ObjectID: objectID})
+}
+
+func (me *Tenant) schemaVersion(schemaID string, requested string) string {
+	if len(requested) > 0 {
+		return requested
+	}
+	if s, found := me.schemas[schemaID]; found && len(s.Version) > 0 {
+		return s.Version
+	}
+	return "1.0.0"
+}
+
+// validateSettingsObject checks the value and scope of an object against its schema (if registered)
+func (me *Tenant) validateSettingsObject(schemaID string, scope string, value json.RawMessage) *errorDetails {
+	if len(schemaID) == 0 {
+		return &errorDetails{Code: http.StatusBadRequest, Message: "Constraints violated.", ConstraintViolations: []constraintViolation{{Path: "schemaId", Message: "must not be null"}}}
+	}
+	var m map[string]any
+	if err := json.Unmarshal(value, &m); err != nil || m == nil {
+		return &errorDetails{Code: http.StatusBadRequest, Message: "Constraints violated.", ConstraintViolations: []constraintViolation{{Path: "value", Message: "must be an object"}}}
+	}
+	s, found := me.schemas[schemaID]
+	if !found || len(s.AllowedScopes) == 0 {
+		return nil
+	}
+	scopeType := scope
+	if scope != "environment" {
+		if idx := strings.LastIndex(scope, "-"); idx > 0 {
+			scopeType = scope[:idx]
+		}
+	}
+	for _, allowedScope := range s.AllowedScopes {
+		if allowedScope == scopeType {
+			return nil
+		}
+	}
+	return &errorDetails{Code: http.StatusBadRequest, Message: "Constraints violated.", ConstraintViolations: []constraintViolation{{
+		Path:    "scope",
+		Message: fmt.Sprintf("Scope '%s' is not allowed for schema '%s'. Allowed scopes: %s", scope, schemaID, strings.Join(s.AllowedScopes, ", ")),
+	}}}
+}
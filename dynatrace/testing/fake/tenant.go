@@ -0,0 +1,138 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Package fake provides an in-process Dynatrace environment based on `httptest`.
+// It covers the Settings 2.0 API, the Configuration API (v1) as used via `settings.NewCRUDService`
+// and the SLO, credentials and network zone endpoints of the Environment API (v2).
+// Settings 2.0 schemas are getting seeded from the `schema.json` files of the packages under test.
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ENABLED_ENV_VAR enables the fake environment for `api.TestAcc` and `api.SettingsTest`
+// in case no real environment has been configured via `DYNATRACE_ENV_URL` and `DYNATRACE_API_TOKEN`
+const ENABLED_ENV_VAR = "DYNATRACE_FAKE_TENANT"
+
+// Token is the API token the fake environment accepts
+const Token = "dt0c01.FAKE.TOKEN"
+
+type Tenant struct {
+	Server *httptest.Server
+
+	mu          sync.Mutex
+	schemas     map[string]*schema
+	settings    *settingsStore
+	collections map[string]*collection
+}
+
+// NewTenant starts a new, empty fake environment. It needs to get stopped via `Close`
+func NewTenant() *Tenant {
+	tenant := &Tenant{
+		schemas:     map[string]*schema{},
+		settings:    newSettingsStore(),
+		collections: map[string]*collection{},
+	}
+	tenant.Server = httptest.NewServer(tenant)
+	return tenant
+}
+
+// URL returns the environment URL of the fake environment
+func (me *Tenant) URL() string {
+	return me.Server.URL
+}
+
+func (me *Tenant) Close() {
+	me.Server.Close()
+}
+
+// Setup starts a fake environment for the given test, in case `DYNATRACE_FAKE_TENANT` is set to `true`
+// and no real environment has been configured. The environment variables `DYNATRACE_ENV_URL` and
+// `DYNATRACE_API_TOKEN` are getting pointed to it for the duration of the test.
+// The Settings 2.0 schemas are getting loaded from the `schema.json` files within the given folders.
+func Setup(t testing.TB, schemaFolders ...string) *Tenant {
+	t.Helper()
+	if os.Getenv(ENABLED_ENV_VAR) != "true" {
+		return nil
+	}
+	if len(os.Getenv("DYNATRACE_ENV_URL")) > 0 && len(os.Getenv("DYNATRACE_API_TOKEN")) > 0 {
+		return nil
+	}
+	tenant := NewTenant()
+	t.Cleanup(tenant.Close)
+	for _, folder := range schemaFolders {
+		if err := tenant.LoadSchemas(folder); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("DYNATRACE_ENV_URL", tenant.URL())
+	t.Setenv("DYNATRACE_API_TOKEN", Token)
+	return tenant
+}
+
+func (me *Tenant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Api-Token "+Token {
+		writeError(w, http.StatusUnauthorized, "Missing authorization parameter.")
+		return
+	}
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/api/v2/settings/"):
+		me.serveSettings(w, r)
+	case strings.HasPrefix(r.URL.Path, "/api/"):
+		me.serveCollections(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+type errorEnvelope struct {
+	Error *errorDetails `json:"error"`
+}
+
+type errorDetails struct {
+	Code                 int                   `json:"code"`
+	Message              string                `json:"message"`
+	ConstraintViolations []constraintViolation `json:"constraintViolations,omitempty"`
+}
+
+type constraintViolation struct {
+	Path              string `json:"path,omitempty"`
+	Message           string `json:"message"`
+	ParameterLocation string `json:"parameterLocation,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string, violations ...constraintViolation) {
+	writeJSON(w, status, errorEnvelope{Error: &errorDetails{Code: status, Message: message, ConstraintViolations: violations}})
+}
@@ -0,0 +1,204 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package fake_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile"
+	profilesettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/alerting/profile/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/fake"
+)
+
+func newTenant(t *testing.T) *fake.Tenant {
+	t.Helper()
+	tenant := fake.NewTenant()
+	t.Cleanup(tenant.Close)
+	if err := tenant.LoadSchemas("../../api/builtin/alerting/profile"); err != nil {
+		t.Fatal(err)
+	}
+	return tenant
+}
+
+func request(t *testing.T, tenant *fake.Tenant, method string, path string, payload any, v any) int {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, tenant.URL()+path, &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Api-Token "+fake.Token)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestSettingsService(t *testing.T) {
+	tenant := newTenant(t)
+	service := profile.Service(&settings.Credentials{URL: tenant.URL(), Token: fake.Token})
+	ctx := context.Background()
+
+	stub, err := service.Create(ctx, &profilesettings.Profile{Name: "fake-profile"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var record profilesettings.Profile
+	if err := service.Get(ctx, stub.ID, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Name != "fake-profile" {
+		t.Errorf("expected name `fake-profile` but got `%s`", record.Name)
+	}
+
+	record.Name = "renamed-profile"
+	if err := service.Update(ctx, stub.ID, &record); err != nil {
+		t.Fatal(err)
+	}
+	stubs, err := service.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stubs) != 1 || stubs[0].Name != "renamed-profile" {
+		t.Errorf("expected exactly one record named `renamed-profile` but got %v", stubs)
+	}
+
+	if err := service.Delete(ctx, stub.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Get(ctx, stub.ID, &record); err == nil {
+		t.Error("expected an error when fetching a deleted record")
+	}
+}
+
+func TestSettingsOrdering(t *testing.T) {
+	tenant := newTenant(t)
+
+	create := func(name string, insertAfter *string) string {
+		var responses []struct {
+			ObjectID string `json:"objectId"`
+		}
+		payload := []map[string]any{{"schemaId": "ordered:schema", "scope": "environment", "value": map[string]any{"name": name}, "insertAfter": insertAfter}}
+		if status := request(t, tenant, http.MethodPost, "/api/v2/settings/objects", payload, &responses); status != http.StatusOK {
+			t.Fatalf("expected status 200 but got %d", status)
+		}
+		return responses[0].ObjectID
+	}
+	first := create("first", nil)
+	second := create("second", nil)
+	empty := ""
+	third := create("third", &empty)
+	fourth := create("fourth", &first)
+
+	var list struct {
+		Items []struct {
+			ObjectID string `json:"objectId"`
+		} `json:"items"`
+		NextPageKey string `json:"nextPageKey"`
+	}
+	ids := []string{}
+	path := "/api/v2/settings/objects?schemaIds=ordered:schema&pageSize=3"
+	for {
+		request(t, tenant, http.MethodGet, path, nil, &list)
+		for _, item := range list.Items {
+			ids = append(ids, item.ObjectID)
+		}
+		if len(list.NextPageKey) == 0 {
+			break
+		}
+		path = "/api/v2/settings/objects?nextPageKey=" + list.NextPageKey
+		list.NextPageKey = ""
+	}
+	expected := []string{third, first, fourth, second}
+	if len(ids) != len(expected) {
+		t.Fatalf("expected %d objects but got %d", len(expected), len(ids))
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("expected object `%s` at position %d but got `%s`", expected[i], i, ids[i])
+		}
+	}
+}
+
+func TestSettingsScopeValidation(t *testing.T) {
+	tenant := newTenant(t)
+
+	var responses []struct {
+		Code  int `json:"code"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	payload := []map[string]any{{"schemaId": profile.SchemaID, "scope": "HOST-1234", "value": map[string]any{"name": "x"}}}
+	if status := request(t, tenant, http.MethodPost, "/api/v2/settings/objects", payload, &responses); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 but got %d", status)
+	}
+	if len(responses) != 1 || responses[0].Error == nil {
+		t.Errorf("expected a constraint violation for scope `HOST-1234`")
+	}
+}
+
+func TestCollections(t *testing.T) {
+	tenant := newTenant(t)
+
+	var stub struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if status := request(t, tenant, http.MethodPost, "/api/config/v1/maintenanceWindows", map[string]any{"name": "window"}, &stub); status != http.StatusCreated {
+		t.Fatalf("expected status 201 but got %d", status)
+	}
+	if stub.Name != "window" {
+		t.Errorf("expected name `window` but got `%s`", stub.Name)
+	}
+	if status := request(t, tenant, http.MethodPut, "/api/config/v1/maintenanceWindows/"+stub.ID, map[string]any{"name": "renamed"}, nil); status != http.StatusNoContent {
+		t.Errorf("expected status 204 but got %d", status)
+	}
+	var list struct {
+		Values []map[string]any `json:"values"`
+	}
+	request(t, tenant, http.MethodGet, "/api/config/v1/maintenanceWindows", nil, &list)
+	if len(list.Values) != 1 || list.Values[0]["name"] != "renamed" || list.Values[0]["id"] != stub.ID {
+		t.Errorf("unexpected list response %v", list.Values)
+	}
+	if status := request(t, tenant, http.MethodDelete, "/api/config/v1/maintenanceWindows/"+stub.ID, nil, nil); status != http.StatusNoContent {
+		t.Errorf("expected status 204 but got %d", status)
+	}
+	if status := request(t, tenant, http.MethodGet, "/api/config/v1/maintenanceWindows/"+stub.ID, nil, nil); status != http.StatusNotFound {
+		t.Errorf("expected status 404 but got %d", status)
+	}
+}
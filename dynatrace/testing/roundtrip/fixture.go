@@ -0,0 +1,173 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package roundtrip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+// maxDepth limits how deep recursive types of a schema are getting expanded
+const maxDepth = 6
+
+// Fixture generates a settings object from the given schema, in the form the Settings 2.0 API
+// would deliver it. Every property whose precondition can be satisfied carries a value.
+// Text properties carry their own path as value, which makes swapped fields visible after a round trip
+func Fixture(sch *validation.Schema) map[string]any {
+	gen := &generator{schema: sch}
+	return gen.object("", sch.Properties, 0)
+}
+
+// ZeroFixture generates a settings object like `Fixture` does, but with booleans set to `false`,
+// numbers as close to zero as their constraints allow and enums set to their last item.
+// These are the values most likely to get lost on the way through HCL, because `ResourceData.GetOk`
+// can't tell them apart from absent ones. Preconditions get evaluated against these values too,
+// which covers the properties `Fixture` leaves out
+func ZeroFixture(sch *validation.Schema) map[string]any {
+	gen := &generator{schema: sch, zero: true}
+	return gen.object("", sch.Properties, 0)
+}
+
+type generator struct {
+	schema *validation.Schema
+	zero   bool
+}
+
+func (me *generator) object(path string, properties map[string]*validation.Property, depth int) map[string]any {
+	object := map[string]any{}
+	if depth > maxDepth {
+		return object
+	}
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if property := properties[key]; property.Precondition == nil {
+			if value := me.property(join(path, key), property, depth); value != nil {
+				object[key] = value
+			}
+		}
+	}
+	// preconditions refer to sibling properties, hence properties depending on them are getting
+	// added until no further precondition gets satisfied by the ones added before
+	for changed := true; changed; {
+		changed = false
+		for _, key := range keys {
+			if _, found := object[key]; found {
+				continue
+			}
+			property := properties[key]
+			if property.Precondition == nil || !property.Precondition.Evaluate(object) {
+				continue
+			}
+			if value := me.property(join(path, key), property, depth); value != nil {
+				object[key] = value
+				changed = true
+			}
+		}
+	}
+	return object
+}
+
+func (me *generator) property(path string, property *validation.Property, depth int) any {
+	if property.Type.Name != "set" && property.Type.Name != "list" {
+		return me.value(path, property.Type, property.Constraints, depth)
+	}
+	if property.Items == nil {
+		return nil
+	}
+	count := 1
+	if property.MinObjects != nil && *property.MinObjects > count {
+		count = *property.MinObjects
+	}
+	elems := []any{}
+	for idx := 0; idx < count; idx++ {
+		elem := me.value(fmt.Sprintf("%s[%d]", path, idx), property.Items.Type, property.Items.Constraints, depth)
+		if elem == nil {
+			return nil
+		}
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+func (me *generator) value(path string, typeRef validation.TypeRef, constraints []*validation.Constraint, depth int) any {
+	if typeName, ok := typeRef.Type(); ok {
+		t, found := me.schema.Types[typeName]
+		if !found || depth >= maxDepth {
+			return nil
+		}
+		return me.object(path, t.Properties, depth+1)
+	}
+	if enumName, ok := typeRef.Enum(); ok {
+		enum, found := me.schema.Enums[enumName]
+		if !found || len(enum.Items) == 0 {
+			return nil
+		}
+		if me.zero {
+			return enum.Items[len(enum.Items)-1].Value
+		}
+		return enum.Items[0].Value
+	}
+	switch typeRef.Name {
+	case "boolean":
+		return !me.zero
+	case "integer":
+		if me.zero {
+			return clamp(0, constraints)
+		}
+		return clamp(1, constraints)
+	case "float":
+		if me.zero {
+			return clamp(0, constraints)
+		}
+		return clamp(1.5, constraints)
+	case "set", "list":
+		// nested collections don't exist in Settings 2.0 schemas
+		return nil
+	}
+	return path
+}
+
+// clamp moves the given number into the range of the first `RANGE` constraint, if any
+func clamp(v float64, constraints []*validation.Constraint) float64 {
+	for _, constraint := range constraints {
+		if constraint.Type != "RANGE" {
+			continue
+		}
+		if constraint.Minimum != nil && v < *constraint.Minimum {
+			v = *constraint.Minimum
+		}
+		if constraint.Maximum != nil && v > *constraint.Maximum {
+			v = *constraint.Maximum
+		}
+	}
+	return v
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,294 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// Package roundtrip checks that settings survive the trip
+// `JSON -> struct -> hcl.Properties -> schema.ResourceData -> struct -> JSON` without losing or altering anything.
+// Anything getting lost on that way surfaces as a perpetual diff in `terraform plan`.
+package roundtrip
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Check unmarshals the given JSON fixture into a fresh instance of the settings produced by `newSettings`
+// and sends it through `MarshalHCL`, the resource schema and `UnmarshalHCL`.
+// The result lists every difference between the JSON and HCL representations before and after the round trip.
+// An empty result means the round trip has been lossless
+func Check(newSettings func() settings.Settings, fixture []byte) (problems []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			problems = append(problems, fmt.Sprintf("panic: %v", r))
+		}
+	}()
+
+	before := newSettings()
+	if err := settings.FromJSON(fixture, before); err != nil {
+		return []string{fmt.Sprintf("unable to unmarshal fixture: %s", err.Error())}
+	}
+	// the services always set the scope of the settings they are reading
+	if scope := settings.GetScope(before); scope != "" {
+		settings.SetScope(before, scope)
+	} else {
+		settings.SetScope(before, "environment")
+	}
+	beforeJSON, err := toJSON(before)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to marshal settings: %s", err.Error())}
+	}
+	properties := hcl.Properties{}
+	if err := before.MarshalHCL(properties); err != nil {
+		return []string{fmt.Sprintf("MarshalHCL failed: %s", err.Error())}
+	}
+	beforeHCL, err := normalizeHCL(properties)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to marshal properties: %s", err.Error())}
+	}
+
+	// exported configuration needs to be accepted by the schema
+	resourceSchema := schema.InternalMap(before.Schema())
+	config := terraform.NewResourceConfigRaw(configurable(before.Schema(), beforeHCL))
+	for _, d := range resourceSchema.Validate(config) {
+		if d.Severity == diag.Error {
+			problems = append(problems, fmt.Sprintf("schema rejects marshalled HCL: %s %s", d.Summary, d.Detail))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	// the state gets populated the same way `Read` does it
+	data, err := resourceSchema.Data(nil, nil)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to produce resource data: %s", err.Error())}
+	}
+	for key, value := range properties {
+		if err := data.Set(key, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: unable to store in state: %s", key, err.Error()))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	after := newSettings()
+	if err := hcl.UnmarshalHCL(after, hcl.DecoderFrom(data)); err != nil {
+		return []string{fmt.Sprintf("UnmarshalHCL failed: %s", err.Error())}
+	}
+	afterJSON, err := toJSON(after)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to marshal settings: %s", err.Error())}
+	}
+	afterHCL, err := toHCL(after)
+	if err != nil {
+		return []string{fmt.Sprintf("MarshalHCL failed: %s", err.Error())}
+	}
+
+	problems = append(problems, compare("json", beforeJSON, afterJSON)...)
+	problems = append(problems, compare("hcl", beforeHCL, afterHCL)...)
+	return problems
+}
+
+// secretPlaceholder is what settings marshal into HCL instead of secrets,
+// because the API never delivers them in clear text
+const secretPlaceholder = "${state.secret_value}"
+
+func toJSON(v settings.Settings) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return normalize(data)
+}
+
+func toHCL(v settings.Settings) (map[string]any, error) {
+	properties := hcl.Properties{}
+	if err := v.MarshalHCL(properties); err != nil {
+		return nil, err
+	}
+	return normalizeHCL(properties)
+}
+
+// normalizeHCL reduces the given properties to the plain form `terraform.NewResourceConfigRaw`
+// expects, i.e. without nil values and with custom types reduced to strings, numbers and booleans
+func normalizeHCL(properties hcl.Properties) (map[string]any, error) {
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+	return normalize(data)
+}
+
+// configurable drops the attributes of the given properties the schema only allows the provider to set
+func configurable(attributes map[string]*schema.Schema, properties map[string]any) map[string]any {
+	result := map[string]any{}
+	for key, value := range properties {
+		attribute, found := attributes[key]
+		if !found {
+			// unknown attributes are left for the schema validation to report
+			result[key] = value
+			continue
+		}
+		if attribute.Computed && !attribute.Optional {
+			continue
+		}
+		resource, ok := attribute.Elem.(*schema.Resource)
+		elems, isList := value.([]any)
+		if !ok || !isList {
+			result[key] = value
+			continue
+		}
+		configurableElems := []any{}
+		for _, elem := range elems {
+			if m, ok := elem.(map[string]any); ok {
+				configurableElems = append(configurableElems, configurable(resource.Schema, m))
+			} else {
+				configurableElems = append(configurableElems, elem)
+			}
+		}
+		result[key] = configurableElems
+	}
+	return result
+}
+
+func normalize(data []byte) (map[string]any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	m, _ := strip(v).(map[string]any)
+	if m == nil {
+		m = map[string]any{}
+	}
+	return m, nil
+}
+
+// strip removes `null` values and empty lists, which are equivalent to absent ones
+// in JSON payloads as well as in HCL
+func strip(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := map[string]any{}
+		for key, elem := range t {
+			if elems, ok := elem.([]any); elem == nil || (ok && len(elems) == 0) {
+				continue
+			}
+			m[key] = strip(elem)
+		}
+		return m
+	case []any:
+		elems := []any{}
+		for _, elem := range t {
+			elems = append(elems, strip(elem))
+		}
+		return elems
+	}
+	return v
+}
+
+func compare(path string, before any, after any) []string {
+	if before == nil && after == nil {
+		return nil
+	}
+	if after == secretPlaceholder {
+		// secrets are getting restored from the state, never from the configuration
+		return nil
+	}
+	if after == nil {
+		return []string{fmt.Sprintf("%s: lost (was `%s`)", path, render(before))}
+	}
+	if before == nil {
+		return []string{fmt.Sprintf("%s: unexpected value `%s`", path, render(after))}
+	}
+	if reflect.TypeOf(before) != reflect.TypeOf(after) {
+		return []string{fmt.Sprintf("%s: type changed from `%s` to `%s`", path, render(before), render(after))}
+	}
+	switch b := before.(type) {
+	case map[string]any:
+		a := after.(map[string]any)
+		keys := map[string]bool{}
+		for key := range b {
+			keys[key] = true
+		}
+		for key := range a {
+			keys[key] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+		problems := []string{}
+		for _, key := range sortedKeys {
+			problems = append(problems, compare(path+"."+key, b[key], a[key])...)
+		}
+		return problems
+	case []any:
+		a := after.([]any)
+		if len(a) != len(b) {
+			return []string{fmt.Sprintf("%s: %d elements became %d", path, len(b), len(a))}
+		}
+		problems := []string{}
+		for idx := range b {
+			problems = append(problems, compare(fmt.Sprintf("%s[%d]", path, idx), b[idx], a[idx])...)
+		}
+		if len(problems) > 0 && sameElements(path, b, a) {
+			// sets are allowed to come back in a different order
+			return nil
+		}
+		return problems
+	}
+	if before != after {
+		return []string{fmt.Sprintf("%s: changed from `%s` to `%s`", path, render(before), render(after))}
+	}
+	return nil
+}
+
+// sameElements checks whether every element of `before` has a counterpart in `after`, regardless of their order
+func sameElements(path string, before []any, after []any) bool {
+	matched := make([]bool, len(after))
+	for _, b := range before {
+		found := false
+		for idx, a := range after {
+			if !matched[idx] && len(compare(path, b, a)) == 0 {
+				matched[idx] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func render(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
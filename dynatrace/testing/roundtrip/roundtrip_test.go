@@ -0,0 +1,126 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package roundtrip_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/roundtrip"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type sample struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	lossy       bool
+}
+
+func (me *sample) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name":        {Type: schema.TypeString, Required: true},
+		"description": {Type: schema.TypeString, Optional: true},
+	}
+}
+
+func (me *sample) MarshalHCL(properties hcl.Properties) error {
+	return properties.EncodeAll(map[string]any{
+		"name":        me.Name,
+		"description": me.Description,
+	})
+}
+
+func (me *sample) UnmarshalHCL(decoder hcl.Decoder) error {
+	if me.lossy {
+		return decoder.Decode("name", &me.Name)
+	}
+	return decoder.DecodeAll(map[string]any{
+		"name":        &me.Name,
+		"description": &me.Description,
+	})
+}
+
+func TestCheck(t *testing.T) {
+	fixture := []byte(`{"name":"a","description":"b"}`)
+	if problems := roundtrip.Check(func() settings.Settings { return &sample{} }, fixture); len(problems) > 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := roundtrip.Check(func() settings.Settings { return &sample{lossy: true} }, fixture)
+	if !strings.Contains(strings.Join(problems, "\n"), "json.description: lost") {
+		t.Errorf("expected `description` to get reported as lost, got %v", problems)
+	}
+}
+
+func TestFixture(t *testing.T) {
+	sch, err := validation.Parse([]byte(`{
+		"schemaId": "builtin:sample",
+		"properties": {
+			"kpm": {"type": {"$ref": "#/enums/Kpm"}},
+			"fallback": {"type": "text", "precondition": {"type": "NOT", "precondition": {"type": "EQUALS", "property": "kpm", "expectedValue": "DURATION"}}},
+			"threshold": {"type": "integer", "constraints": [{"type": "RANGE", "minimum": 5, "maximum": 10}]}
+		},
+		"enums": {"Kpm": {"items": [{"value": "DURATION"}, {"value": "VISUALLY_COMPLETE"}]}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture := roundtrip.Fixture(sch)
+	if fixture["kpm"] != "DURATION" {
+		t.Errorf("expected `kpm` to be `DURATION`, got %v", fixture["kpm"])
+	}
+	if _, found := fixture["fallback"]; found {
+		t.Error("`fallback` is expected to be hidden by its precondition")
+	}
+	if fixture["threshold"] != float64(5) {
+		t.Errorf("expected `threshold` to respect its range, got %v", fixture["threshold"])
+	}
+
+	zero := roundtrip.ZeroFixture(sch)
+	if zero["kpm"] != "VISUALLY_COMPLETE" {
+		t.Errorf("expected `kpm` to be `VISUALLY_COMPLETE`, got %v", zero["kpm"])
+	}
+	if zero["fallback"] != "fallback" {
+		t.Errorf("expected `fallback` to be revealed by its precondition, got %v", zero["fallback"])
+	}
+	if zero["threshold"] != float64(5) {
+		t.Errorf("expected `threshold` to respect its range, got %v", zero["threshold"])
+	}
+}
+
+func TestZeroFixture(t *testing.T) {
+	sch, err := validation.Parse([]byte(`{
+		"schemaId": "builtin:sample",
+		"properties": {
+			"enabled": {"type": "boolean"},
+			"count": {"type": "integer"},
+			"ratio": {"type": "float", "constraints": [{"type": "RANGE", "minimum": -1, "maximum": 1}]}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixture := roundtrip.Fixture(sch); fixture["enabled"] != true || fixture["count"] != float64(1) {
+		t.Errorf("expected `true` and `1`, got %v", fixture)
+	}
+	if fixture := roundtrip.ZeroFixture(sch); fixture["enabled"] != false || fixture["count"] != float64(0) || fixture["ratio"] != float64(0) {
+		t.Errorf("expected `false` and `0`, got %v", fixture)
+	}
+}
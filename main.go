@@ -23,6 +23,7 @@ import (
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
@@ -104,8 +105,12 @@ import (
 // 	return nil
 // }
 
+// version is set via `-ldflags "-X main.version=..."` when building a release
+var version = "dev"
+
 func main() {
 	defer export.CleanUp.Finish()
+	rest.Version = version
 
 	if dynatrace.Export(os.Args) {
 		return
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
@@ -37,6 +38,9 @@ type OauthCredentials struct {
 	ClientSecret string
 	TokenURL     string
 	Scopes       []string
+	// AuthStyle defines how the client credentials are getting sent to the token endpoint.
+	// By default it is getting auto detected
+	AuthStyle oauth2.AuthStyle
 }
 
 // NewTokenAuthClient creates a new HTTP client that supports token based authorization
@@ -48,8 +52,11 @@ func NewTokenAuthClient(token string) *http.Client {
 	return &http.Client{Transport: NewTokenAuthTransport(token)}
 }
 
-// NewOAuthClient creates a new HTTP client that supports OAuth2 client credentials based authorization
+// NewOAuthClient creates a new HTTP client that supports OAuth2 client credentials based authorization.
+// Unless the context specifies a different HTTP client via `oauth2.HTTPClient`, token requests as well as
+// authenticated requests are getting sent via the HTTP client shared by all API clients of the provider
 func NewOAuthClient(ctx context.Context, oauthConfig OauthCredentials) *http.Client {
+	ctx = withHTTPClient(ctx)
 
 	tokenUrl := oauthConfig.TokenURL
 	if tokenUrl == "" {
@@ -62,7 +69,31 @@ func NewOAuthClient(ctx context.Context, oauthConfig OauthCredentials) *http.Cli
 		ClientSecret: oauthConfig.ClientSecret,
 		TokenURL:     tokenUrl,
 		Scopes:       oauthConfig.Scopes,
+		AuthStyle:    oauthConfig.AuthStyle,
 	}
 
 	return config.Client(ctx)
 }
+
+// NewPlatformTokenClient creates a new HTTP client that authenticates its requests with the given platform token
+func NewPlatformTokenClient(ctx context.Context, platformToken string) *http.Client {
+	return oauth2.NewClient(withHTTPClient(ctx), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: platformToken, TokenType: "Bearer"}))
+}
+
+// NewPlatformClient creates a new HTTP client for the platform APIs. Requests are getting authenticated
+// with the given platform token, or via OAuth2 client credentials in case no platform token has been specified
+func NewPlatformClient(ctx context.Context, platformToken string, oauthConfig OauthCredentials) *http.Client {
+	if len(platformToken) > 0 {
+		return NewPlatformTokenClient(ctx, platformToken)
+	}
+	return NewOAuthClient(ctx, oauthConfig)
+}
+
+// withHTTPClient makes the HTTP client shared by all API clients of the provider the base
+// of OAuth2 clients, unless the given context already specifies one via `oauth2.HTTPClient`
+func withHTTPClient(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); !ok {
+		return context.WithValue(ctx, oauth2.HTTPClient, rest.HTTPClient())
+	}
+	return ctx
+}
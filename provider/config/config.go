@@ -23,7 +23,9 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,6 +38,8 @@ type IAM struct {
 	ClientID     string
 	AccountID    string
 	ClientSecret string
+	EndpointURL  string
+	TokenURL     string
 }
 
 type Automation struct {
@@ -43,6 +47,7 @@ type Automation struct {
 	ClientSecret   string
 	TokenURL       string
 	EnvironmentURL string
+	PlatformToken  string
 }
 
 const (
@@ -83,11 +88,20 @@ func validateCredentials(conf *ProviderConfiguration, CredentialValidation int)
 			return fmt.Errorf("No Cluster URL has been specified. Use either the environment variable `DT_CLUSTER_URL` or the configuration attribute `dt_cluster_url` of the provider for that.")
 		}
 	case CredValAutomation:
+		if len(conf.Automation.PlatformToken) > 0 {
+			if len(conf.Automation.EnvironmentURL) == 0 {
+				return fmt.Errorf("A Platform Token has been specified, but no Environment URL for the Automation API. Use either the environment variable `DT_AUTOMATION_ENVIRONMENT_URL` or the configuration attribute `automation_env_url` of the provider for that. For SaaS environments it is derived from `dt_env_url` (`https://######.apps.dynatrace.com`).")
+			}
+			return nil
+		}
+		if len(conf.Automation.ClientID) == 0 && len(conf.Automation.ClientSecret) == 0 {
+			return fmt.Errorf("Neither a Platform Token nor OAuth Client Credentials for the Automation API have been specified. Use either the environment variable `DT_PLATFORM_TOKEN` or the configuration attribute `platform_token` of the provider for authenticating with a Platform Token. Alternatively use the environment variables `DT_AUTOMATION_CLIENT_ID` and `DT_AUTOMATION_CLIENT_SECRET` or the configuration attributes `automation_client_id` and `automation_client_secret` of the provider for authenticating with an OAuth Client.")
+		}
 		if len(conf.Automation.ClientID) == 0 {
-			return fmt.Errorf("No OAuth Client ID for the Automation API has been specified. Use either the environment variable `DT_AUTOMATION_CLIENT_ID` or the configuration attribute `automation_client_id` of the provider for that.")
+			return fmt.Errorf("No OAuth Client ID for the Automation API has been specified. Use either the environment variable `DT_AUTOMATION_CLIENT_ID` or the configuration attribute `automation_client_id` of the provider for that. Alternatively authenticate with a Platform Token via the environment variable `DT_PLATFORM_TOKEN` or the configuration attribute `platform_token`.")
 		}
 		if len(conf.Automation.ClientSecret) == 0 {
-			return fmt.Errorf("No OAuth Client Secret for the Automation API has been specified. Use either the environment variable `DT_AUTOMATION_CLIENT_SECRET` or the configuration attribute `automation_client_secret` of the provider for that.")
+			return fmt.Errorf("No OAuth Client Secret for the Automation API has been specified. Use either the environment variable `DT_AUTOMATION_CLIENT_SECRET` or the configuration attribute `automation_client_secret` of the provider for that. Alternatively authenticate with a Platform Token via the environment variable `DT_PLATFORM_TOKEN` or the configuration attribute `platform_token`.")
 		}
 		if len(conf.Automation.TokenURL) == 0 {
 			return fmt.Errorf("No Token URL for the Automation API has been specified. Use either the environment variable `DT_AUTOMATION_TOKEN_URL` or the configuration attribute `automation_token_url` of the provider for that.")
@@ -100,7 +114,16 @@ func validateCredentials(conf *ProviderConfiguration, CredentialValidation int)
 }
 
 func Credentials(m any, CredentialValidation int) (*settings.Credentials, error) {
-	conf := m.(*ProviderConfiguration)
+	return EnvironmentCredentials(m, "", CredentialValidation)
+}
+
+// EnvironmentCredentials returns the credentials for the environment with the given name (meta argument `environment`).
+// An empty name refers to the environment configured at the provider level
+func EnvironmentCredentials(m any, environment string, CredentialValidation int) (*settings.Credentials, error) {
+	conf, err := m.(*ProviderConfiguration).ForEnvironment(environment)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateCredentials(conf, CredentialValidation); err != nil {
 		return nil, err
 	}
@@ -109,6 +132,7 @@ func Credentials(m any, CredentialValidation int) (*settings.Credentials, error)
 		URL:        conf.EnvironmentURL,
 		IAM:        conf.IAM,
 		Automation: conf.Automation,
+		HTTPClient: rest.HTTPClient(),
 	}, nil
 }
 
@@ -123,6 +147,10 @@ type ProviderConfiguration struct {
 	APIToken          string
 	IAM               IAM
 	Automation        Automation
+	RetryPolicy       rest.RetryPolicy
+	ValidateOnly      bool
+	CheckScopes       bool
+	Environments      map[string]*ProviderConfiguration
 }
 
 type Getter interface {
@@ -138,6 +166,29 @@ var regexpSprintTenant = regexp.MustCompile(`https:\/\/(.*).sprint(?:\.apps)?.dy
 var regexpDevTenant = regexp.MustCompile(`https:\/\/(.*).dev(?:\.apps)?.dynatracelabs.com`)
 
 func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnostics) {
+	retryPolicy, err := getRetryPolicy(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	rest.SetRetryPolicy(retryPolicy)
+
+	httpClient, err := rest.NewHTTPClient(getHTTPOptions(d))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	rest.SetHTTPClient(httpClient)
+
+	conf := configure(d)
+	conf.RetryPolicy = retryPolicy
+	conf.ValidateOnly, _ = d.Get("validate_only").(bool)
+	conf.CheckScopes, _ = d.Get("check_scopes").(bool)
+	if conf.Environments, err = getEnvironments(d); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return conf, diag.Diagnostics{}
+}
+
+func configure(d Getter) *ProviderConfiguration {
 	dtEnvURL := d.Get("dt_env_url").(string)
 	apiToken := d.Get("dt_api_token").(string)
 	clusterAPIToken := getString(d, "dt_cluster_api_token")
@@ -205,8 +256,6 @@ func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnost
 		automation_client_secret = client_secret
 	}
 
-	var diags diag.Diagnostics
-
 	return &ProviderConfiguration{
 		EnvironmentURL:    dtEnvURL,
 		DTenvURL:          fullURL,
@@ -219,14 +268,152 @@ func ProviderConfigureGeneric(ctx context.Context, d Getter) (any, diag.Diagnost
 			ClientID:     iam_client_id,
 			AccountID:    iam_account_id,
 			ClientSecret: getString(d, "iam_client_secret"),
+			EndpointURL:  strings.TrimSuffix(strings.TrimSpace(getString(d, "iam_endpoint_url")), "/"),
+			TokenURL:     strings.TrimSpace(getString(d, "iam_token_url")),
 		},
 		Automation: Automation{
 			ClientID:       getString(d, "automation_client_id"),
 			ClientSecret:   getString(d, "automation_client_secret"),
 			TokenURL:       automationTokenURL,
 			EnvironmentURL: automationEnvironmentURL,
+			PlatformToken:  strings.TrimSpace(getString(d, "platform_token")),
 		},
-	}, diags
+	}
+}
+
+// environmentGetter provides the attributes of an entry within the `environments` of the provider
+// under the names of the corresponding provider attributes
+type environmentGetter map[string]any
+
+var environmentAttributes = map[string]string{
+	"dt_env_url":               "url",
+	"dt_api_token":             "api_token",
+	"client_id":                "client_id",
+	"client_secret":            "client_secret",
+	"account_id":               "account_id",
+	"iam_client_id":            "client_id",
+	"iam_client_secret":        "client_secret",
+	"iam_account_id":           "account_id",
+	"automation_client_id":     "client_id",
+	"automation_client_secret": "client_secret",
+	"automation_env_url":       "automation_env_url",
+	"automation_token_url":     "automation_token_url",
+	"iam_endpoint_url":         "iam_endpoint_url",
+	"iam_token_url":            "iam_token_url",
+	"platform_token":           "platform_token",
+}
+
+func (me environmentGetter) Get(key string) any {
+	if attr, found := environmentAttributes[key]; found {
+		if value, found := me[attr]; found && value != nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// getEnvironments reads the `environments` configured for the provider, keyed by their names
+func getEnvironments(d Getter) (map[string]*ProviderConfiguration, error) {
+	environments := map[string]*ProviderConfiguration{}
+	entries, _ := d.Get("environments").([]any)
+	for _, entry := range entries {
+		attrs, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := attrs["name"].(string)
+		if _, found := environments[name]; found {
+			return nil, fmt.Errorf("the environment `%s` is configured more than once", name)
+		}
+		environments[name] = configure(environmentGetter(attrs))
+	}
+	return environments, nil
+}
+
+// ValidateOnly signals whether the provider has been configured via `validate_only` to send
+// the settings of resources getting created or modified to the validation endpoints only.
+// The flag applies to all `environments` configured for the provider
+func ValidateOnly(m any) bool {
+	conf, ok := m.(*ProviderConfiguration)
+	return ok && conf.ValidateOnly
+}
+
+// ForEnvironment returns the configuration of the environment with the given name, configured
+// within the `environments` of the provider. An empty name refers to the provider configuration itself
+func (me *ProviderConfiguration) ForEnvironment(name string) (*ProviderConfiguration, error) {
+	if len(name) == 0 {
+		return me, nil
+	}
+	if environment, found := me.Environments[name]; found {
+		return environment, nil
+	}
+	return nil, fmt.Errorf("The environment `%s` has not been configured. Add it to the `environments` of the provider.", name)
+}
+
+// getRetryPolicy reads the attributes `max_retries`, `min_backoff` and `max_backoff`.
+// Attributes not configured keep the values of the currently effective retry policy
+func getRetryPolicy(d Getter) (rest.RetryPolicy, error) {
+	policy := rest.GetRetryPolicy()
+	if maxRetries, ok := d.Get("max_retries").(int); ok {
+		policy.MaxRetries = maxRetries
+	}
+	if minBackoff := getString(d, "min_backoff"); len(minBackoff) > 0 {
+		duration, err := time.ParseDuration(minBackoff)
+		if err != nil {
+			return policy, fmt.Errorf("invalid value `%s` for `min_backoff`: %s", minBackoff, err.Error())
+		}
+		policy.MinBackoff = duration
+	}
+	if maxBackoff := getString(d, "max_backoff"); len(maxBackoff) > 0 {
+		duration, err := time.ParseDuration(maxBackoff)
+		if err != nil {
+			return policy, fmt.Errorf("invalid value `%s` for `max_backoff`: %s", maxBackoff, err.Error())
+		}
+		policy.MaxBackoff = duration
+	}
+	if policy.MinBackoff > policy.MaxBackoff {
+		return policy, fmt.Errorf("`min_backoff` (%s) must not exceed `max_backoff` (%s)", policy.MinBackoff, policy.MaxBackoff)
+	}
+	return policy, nil
+}
+
+// getHTTPOptions configures CA certificates, client certificate and proxy of the HTTP client shared by all API clients.
+// Settings not configured within the provider block are taken from the environment
+func getHTTPOptions(d Getter) rest.HTTPOptions {
+	options := rest.DefaultHTTPOptions()
+	if caCertificates := getString(d, "ca_certificates"); len(caCertificates) > 0 {
+		options.CACertificates = caCertificates
+	}
+	if clientCertificate := getString(d, "client_certificate"); len(clientCertificate) > 0 {
+		options.ClientCertificate = clientCertificate
+	}
+	if clientKey := getString(d, "client_key"); len(clientKey) > 0 {
+		options.ClientKey = clientKey
+	}
+	if httpsProxy := getString(d, "https_proxy"); len(httpsProxy) > 0 {
+		options.HTTPSProxy = httpsProxy
+	}
+	if noProxy := getString(d, "no_proxy"); len(noProxy) > 0 {
+		options.NoProxy = noProxy
+	}
+	return options
+}
+
+// ValidateDuration accepts strings parseable by `time.ParseDuration`, like `500ms`, `2s` or `1m`
+func ValidateDuration(v any, k string) (warnings []string, errs []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of `%s` to be string", k)}
+	}
+	if len(s) == 0 {
+		return nil, nil
+	}
+	if duration, err := time.ParseDuration(s); err != nil {
+		errs = append(errs, fmt.Errorf("`%s` is not a valid duration for `%s`, e.g. `500ms`, `2s` or `1m`", s, k))
+	} else if duration <= 0 {
+		errs = append(errs, fmt.Errorf("`%s` must be a positive duration", k))
+	}
+	return nil, errs
 }
 
 func getString(d Getter, key string) string {
@@ -19,6 +19,7 @@ package config_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
@@ -43,3 +44,30 @@ func TestProviderConfigure(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestPlatformTokenCredentials(t *testing.T) {
+	ctx := context.TODO()
+	d := mockResourceData{
+		"dt_env_url":     "https://something.live.dynatrace.com",
+		"dt_api_token":   "faketoken",
+		"platform_token": "dt0s16.faketoken",
+	}
+
+	result, _ := config.ProviderConfigureGeneric(ctx, d)
+	credentials, err := config.Credentials(result, config.CredValAutomation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials.Automation.PlatformToken != "dt0s16.faketoken" {
+		t.Errorf("expected platform token `dt0s16.faketoken`, but got `%s`", credentials.Automation.PlatformToken)
+	}
+	if credentials.Automation.EnvironmentURL != "https://something.apps.dynatrace.com" {
+		t.Errorf("expected environment URL `https://something.apps.dynatrace.com`, but got `%s`", credentials.Automation.EnvironmentURL)
+	}
+
+	delete(d, "platform_token")
+	result, _ = config.ProviderConfigureGeneric(ctx, d)
+	if _, err = config.Credentials(result, config.CredValAutomation); err == nil || !strings.Contains(err.Error(), "platform_token") {
+		t.Errorf("expected an error mentioning `platform_token`, but got %v", err)
+	}
+}
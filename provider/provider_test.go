@@ -0,0 +1,111 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestCheckScopesPerEnvironment(t *testing.T) {
+	grantedScopes := map[string][]string{
+		"dt0c01.default": {"settings.read", "settings.write"},
+		"dt0c01.prod":    {"settings.read"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Token string `json:"token"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		scopes, found := grantedScopes[payload.Token]
+		if r.URL.Path != "/api/v2/apiTokens/lookup" || !found {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"scopes": scopes})
+	}))
+	defer server.Close()
+
+	folder := t.TempDir()
+	configuration := `
+resource "dynatrace_alerting" "default" {
+  name = "default"
+}
+
+resource "dynatrace_alerting" "prod" {
+  environment = "prod"
+  name        = "prod"
+}
+
+resource "dynatrace_alerting" "variable" {
+  environment = var.environment
+  name        = "variable"
+}
+`
+	if err := os.WriteFile(filepath.Join(folder, "main.tf"), []byte(configuration), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// modules aren't part of the check - neither their resources nor files Terraform wouldn't parse
+	module := `
+resource "dynatrace_alerting" "staging" {
+  environment = "staging"
+  name        = "staging"
+`
+	if err := os.MkdirAll(filepath.Join(folder, "modules", "alerting"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "modules", "alerting", "main.tf"), []byte(module), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(folder); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	m := newProviderConfiguration(server.URL, "dt0c01.default")
+	m.Environments = map[string]*config.ProviderConfiguration{"prod": newProviderConfiguration(server.URL, "dt0c01.prod")}
+
+	diags := checkScopes(context.Background(), m)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "environment `prod`") || !strings.Contains(diags[0].Detail, "settings.write") {
+		t.Errorf("expected a warning about the credentials of environment `prod`, got %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if diags[1].Severity != diag.Warning || !strings.Contains(diags[1].Summary, "`var.environment`") {
+		t.Errorf("expected a warning about the environment `var.environment`, got %s: %s", diags[1].Summary, diags[1].Detail)
+	}
+}
+
+func newProviderConfiguration(environmentURL string, apiToken string) *config.ProviderConfiguration {
+	return &config.ProviderConfiguration{EnvironmentURL: environmentURL, APIToken: apiToken}
+}
@@ -60,6 +60,35 @@ type Generic struct {
 	Type                 export.ResourceType
 	Descriptor           export.ResourceDescriptor
 	CredentialValidation int
+	// EnvironmentMetaArgument is true if the resource offers the meta argument `environment`.
+	// Resources defining an attribute `environment` on their own don't
+	EnvironmentMetaArgument bool
+}
+
+// EnvironmentAttribute is the meta argument assigning a resource to one of the `environments` configured for the provider
+const EnvironmentAttribute = "environment"
+
+// EnvironmentImportSeparator separates the name of the environment from the ID of the resource
+// within import IDs, e.g. `prod#vu9U3hXa3q0AAAAB...`
+const EnvironmentImportSeparator = "#"
+
+// ImportStateEnvironment imports resources into the environment named by the prefix of the import ID (`<environment>#<id>`)
+// and sets their `environment` accordingly. Import IDs not starting with the name of one of the `environments`
+// configured for the provider are getting imported from the environment configured for the provider itself.
+func ImportStateEnvironment(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	environment, id, found := strings.Cut(d.Id(), EnvironmentImportSeparator)
+	if !found || len(environment) == 0 {
+		return []*schema.ResourceData{d}, nil
+	}
+	// IDs of some resources contain the separator on their own
+	if conf, ok := m.(*config.ProviderConfiguration); !ok || conf.Environments[environment] == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+	d.SetId(id)
+	if err := d.Set(EnvironmentAttribute, environment); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
 }
 
 type Deprecated interface {
@@ -119,6 +148,19 @@ func (me *Generic) Resource() *schema.Resource {
 		}
 	}
 	updateableAttrs := len(sch) - len(nonUpdateableAttrs)
+	if _, found := sch[EnvironmentAttribute]; !found {
+		me.EnvironmentMetaArgument = true
+		sch[EnvironmentAttribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The name of one of the `environments` configured for the provider this resource should be managed in. Defaults to the environment configured for the provider itself",
+		}
+	}
+	importer := &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext}
+	if me.EnvironmentMetaArgument {
+		importer = &schema.ResourceImporter{StateContext: ImportStateEnvironment}
+	}
 
 	if dep, ok := stngs.(Deprecated); ok {
 		resRes := &schema.Resource{
@@ -126,15 +168,13 @@ func (me *Generic) Resource() *schema.Resource {
 			CreateContext:      logging.Enable(me.Create),
 			ReadContext:        logging.Enable(me.Read),
 			DeleteContext:      logging.Enable(me.Delete),
-			Importer:           &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
+			Importer:           importer,
 			DeprecationMessage: dep.Deprecated(),
 		}
 		if updateableAttrs > 0 {
 			resRes.UpdateContext = logging.Enable(me.Update)
 		}
-		if dc, ok := stngs.(DiffCustomizer); ok {
-			resRes.CustomizeDiff = dc.CustomizeDiff
-		}
+		resRes.CustomizeDiff = me.customizeDiff(stngs, sch)
 		return resRes
 	}
 
@@ -143,61 +183,56 @@ func (me *Generic) Resource() *schema.Resource {
 		CreateContext: logging.Enable(me.Create),
 		ReadContext:   logging.Enable(me.Read),
 		DeleteContext: logging.Enable(me.Delete),
-		Importer:      &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
+		Importer:      importer,
 	}
 	if updateableAttrs > 0 {
 		resRes.UpdateContext = logging.Enable(me.Update)
 	}
-	if dc, ok := stngs.(DiffCustomizer); ok {
-		resRes.CustomizeDiff = dc.CustomizeDiff
-	}
+	resRes.CustomizeDiff = me.customizeDiff(stngs, sch)
 	return resRes
 }
 
-func (me *Generic) createCredentials(m any) *settings.Credentials {
-	conf := m.(*config.ProviderConfiguration)
-	return &settings.Credentials{
-		Token:      conf.APIToken,
-		URL:        conf.EnvironmentURL,
-		IAM:        conf.IAM,
-		Automation: conf.Automation,
-	}
-}
-
-func (me *Generic) validateCredentials(m any) diag.Diagnostics {
-	if me.CredentialValidation != CredValDefault {
-		return diag.Diagnostics{}
+// credentials resolves the credentials of the environment the resource has been assigned to via the meta argument `environment`
+func (me *Generic) credentials(d config.Getter, m any) (*settings.Credentials, diag.Diagnostics) {
+	credentialValidation := config.CredValNone
+	if me.CredentialValidation == CredValDefault {
+		credentialValidation = config.CredValDefault
 	}
-	conf := m.(*config.ProviderConfiguration)
-	if len(conf.EnvironmentURL) == 0 {
-		return diag.Errorf("No Environment URL has been specified. Use either the environment variable `DYNATRACE_ENV_URL` or the configuration attribute `dt_env_url` of the provider for that.")
+	environment := ""
+	if me.EnvironmentMetaArgument {
+		environment, _ = d.Get(EnvironmentAttribute).(string)
 	}
-	if !strings.HasPrefix(conf.EnvironmentURL, "https://") && !strings.HasPrefix(conf.EnvironmentURL, "http://") {
-		return diag.Errorf("The Environment URL `%s` neither starts with `https://` nor with `http://`. Please check your configuration.\nFor SaaS environments: `https://######.live.dynatrace.com`.\nFor Managed environments: `https://############/e/########-####-####-####-############`", conf.EnvironmentURL)
-	}
-	if len(conf.APIToken) == 0 {
-		return diag.Errorf("No API Token has been specified. Use either the environment variable `DYNATRACE_API_TOKEN` or the configuration attribute `dt_api_token` of the provider for that.")
+	credentials, err := config.EnvironmentCredentials(m, environment, credentialValidation)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
-	return diag.Diagnostics{}
+	return credentials, diag.Diagnostics{}
 }
 
 func (me *Generic) Settings() settings.Settings {
 	return me.Descriptor.NewSettings()
 }
 
-func (me *Generic) Service(m any) settings.CRUDService[settings.Settings] {
-	return me.Descriptor.Service(me.createCredentials(m))
+func (me *Generic) service(d config.Getter, m any) (settings.CRUDService[settings.Settings], diag.Diagnostics) {
+	credentials, diags := me.credentials(d, m)
+	if len(diags) > 0 {
+		return nil, diags
+	}
+	return me.Descriptor.Service(credentials), diags
 }
 
 func (me *Generic) Create(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	if diags := me.validateCredentials(m); len(diags) > 0 {
+	service, diags := me.service(d, m)
+	if len(diags) > 0 {
 		return diags
 	}
 	sttngs := me.Settings()
 	if err := hcl.UnmarshalHCL(sttngs, hcl.DecoderFrom(d)); err != nil {
 		return diag.FromErr(err)
 	}
-	service := me.Service(m)
+	if config.ValidateOnly(m) {
+		return me.validateOnly(ctx, service, "", sttngs)
+	}
 	var stub *api.Stub
 	var err error
 	stub, err = service.Create(ctx, sttngs)
@@ -235,7 +270,8 @@ func (me *Generic) Create(ctx context.Context, d *schema.ResourceData, m any) di
 }
 
 func (me *Generic) Update(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	if diags := me.validateCredentials(m); len(diags) > 0 {
+	service, diags := me.service(d, m)
+	if len(diags) > 0 {
 		return diags
 	}
 	sttngs := me.Settings()
@@ -245,7 +281,9 @@ func (me *Generic) Update(ctx context.Context, d *schema.ResourceData, m any) di
 	if err := hcl.UnmarshalHCL(sttngs, hcl.DecoderFrom(d)); err != nil {
 		return diag.FromErr(err)
 	}
-	service := me.Service(m)
+	if config.ValidateOnly(m) {
+		return me.validateOnly(ctx, service, d.Id(), sttngs)
+	}
 	var err error
 	if ctx.Value(settings.ContextKeyStateConfig) == nil {
 		stateConfig := me.Settings()
@@ -279,7 +317,7 @@ type IDGenerator interface {
 }
 
 func (me *Generic) ReadForSettings(ctx context.Context, d *schema.ResourceData, m any, sttngs settings.Settings) diag.Diagnostics {
-	if diags := me.validateCredentials(m); len(diags) > 0 {
+	if _, diags := me.credentials(d, m); len(diags) > 0 {
 		return diags
 	}
 	var err error
@@ -326,14 +364,14 @@ func (me *Generic) ReadForSettings(ctx context.Context, d *schema.ResourceData,
 }
 
 func (me *Generic) Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	if diags := me.validateCredentials(m); len(diags) > 0 {
+	service, diags := me.service(d, m)
+	if len(diags) > 0 {
 		return diags
 	}
 	if strings.HasSuffix(d.Id(), "---flawed----") {
 		return diag.Diagnostics{}
 	}
 	sttngs := me.Settings()
-	service := me.Service(m)
 
 	var err error
 	if ctx.Value(settings.ContextKeyStateConfig) == nil {
@@ -375,13 +413,17 @@ func (me *Generic) Read(ctx context.Context, d *schema.ResourceData, m any) diag
 }
 
 func (me *Generic) Delete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	if diags := me.validateCredentials(m); len(diags) > 0 {
+	service, diags := me.service(d, m)
+	if len(diags) > 0 {
 		return diags
 	}
 	if strings.HasSuffix(d.Id(), "---flawed----") {
 		d.SetId("")
 		return diag.Diagnostics{}
 	}
+	if config.ValidateOnly(m) {
+		return validateOnlyNoDelete(string(me.Type))
+	}
 	// if the state offers an attribute _restore_ deletion essentially means
 	// to restore the settings persisted within that attribute.
 	// If the attribute doesn't contain usable data we're deleting as usual
@@ -393,14 +435,13 @@ func (me *Generic) Delete(ctx context.Context, d *schema.ResourceData, m any) di
 			if err := json.Unmarshal([]byte(restore), sttngs); err != nil {
 				return diag.FromErr(err)
 			}
-			if err := me.Service(m).Update(ctx, d.Id(), sttngs); err != nil {
+			if err := service.Update(ctx, d.Id(), sttngs); err != nil {
 				return diag.FromErr(err)
 			}
 			d.SetId("")
 			return diag.Diagnostics{}
 		}
 	}
-	service := me.Service(m)
 	var err error
 	if ctx.Value(settings.ContextKeyStateConfig) == nil {
 		stateConfig := me.Settings()
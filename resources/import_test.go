@@ -0,0 +1,60 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+)
+
+func TestImportStateEnvironment(t *testing.T) {
+	m := &config.ProviderConfiguration{Environments: map[string]*config.ProviderConfiguration{"prod": {}}}
+	tests := []struct {
+		importID    string
+		id          string
+		environment string
+	}{
+		{"vu9U3hXa3q0AAAAB", "vu9U3hXa3q0AAAAB", ""},
+		{"prod#vu9U3hXa3q0AAAAB", "vu9U3hXa3q0AAAAB", "prod"},
+		// not a configured environment, hence part of the ID
+		{"policy#-#global#-#global", "policy#-#global#-#global", ""},
+		{"#vu9U3hXa3q0AAAAB", "#vu9U3hXa3q0AAAAB", ""},
+	}
+	resource := NewGeneric(export.ResourceTypes.Alerting).Resource()
+	for _, test := range tests {
+		t.Run(test.importID, func(t *testing.T) {
+			d := resource.Data(nil)
+			d.SetId(test.importID)
+			result, err := resource.Importer.StateContext(context.Background(), d, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result) != 1 {
+				t.Fatalf("expected a single resource, got %d", len(result))
+			}
+			if id := result[0].Id(); id != test.id {
+				t.Errorf("expected ID `%s`, got `%s`", test.id, id)
+			}
+			if environment := result[0].Get(EnvironmentAttribute).(string); environment != test.environment {
+				t.Errorf("expected environment `%s`, got `%s`", test.environment, environment)
+			}
+		})
+	}
+}
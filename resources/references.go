@@ -0,0 +1,291 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// REFERENCE_VALIDATION enables resolving the IDs and names of monitored entities and other configuration
+// a resource refers to while Terraform is creating the plan
+var REFERENCE_VALIDATION = os.Getenv("DYNATRACE_REFERENCE_VALIDATION") == "true"
+
+// validateReferences checks whether the monitored entities and configurations the planned settings
+// are referring to via plain strings exist within the environment. The kinds of references
+// a resource may contain are known by the `Dependencies` of its `ResourceDescriptor`.
+// Management zones and request attributes referred to by name are getting looked up among the
+// configurations which already exist. The plan of other resources isn't visible here, hence
+// referring to one created within the same apply gets reported as well (see the documentation).
+func (me *Generic) validateReferences(ctx context.Context, rd *schema.ResourceDiff, m any, schemata map[string]*schema.Schema) error {
+	if !REFERENCE_VALIDATION {
+		return nil
+	}
+	// resources which aren't getting created or modified are not of interest
+	if len(rd.Id()) > 0 && len(rd.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	references := me.Descriptor.References()
+	if len(references) == 0 {
+		return nil
+	}
+	credentials, diags := me.credentials(rd, m)
+	if len(diags) > 0 {
+		return nil
+	}
+
+	values := []*attributeValue{}
+	attrNames := make([]string, 0, len(schemata))
+	for attrName := range schemata {
+		attrNames = append(attrNames, attrName)
+	}
+	sort.Strings(attrNames)
+	for _, attrName := range attrNames {
+		if !rd.NewValueKnown(attrName) {
+			continue
+		}
+		values = collectValues(values, cty.GetAttrPath(attrName), attrName, schemata[attrName], rd.Get(attrName))
+	}
+
+	var path cty.Path
+	messages := []string{}
+	for _, value := range values {
+		message := resolveReferences(ctx, credentials, references, value)
+		if len(message) == 0 {
+			continue
+		}
+		if len(messages) == 0 {
+			path = value.path
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", value.key, message))
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return path.NewError(errors.New(strings.Join(messages, "\n")))
+}
+
+type attributeValue struct {
+	path  cty.Path
+	key   string
+	value string
+}
+
+// collectValues gathers the strings within the given value of an attribute.
+// Elements of sets can't get addressed, hence their values are getting reported for the set itself
+func collectValues(values []*attributeValue, path cty.Path, key string, sch *schema.Schema, value any) []*attributeValue {
+	if sch.Computed && !sch.Optional {
+		// not part of the configuration
+		return values
+	}
+	switch typed := value.(type) {
+	case string:
+		if len(typed) > 0 {
+			values = append(values, &attributeValue{path: path, key: key, value: typed})
+		}
+	case []any:
+		for idx, elem := range typed {
+			values = collectElem(values, path.IndexInt(idx), fmt.Sprintf("%s.%d", key, idx), sch, elem)
+		}
+	case *schema.Set:
+		for _, elem := range typed.List() {
+			values = collectElem(values, path, key, sch, elem)
+		}
+	case map[string]any:
+		mapKeys := make([]string, 0, len(typed))
+		for mapKey := range typed {
+			mapKeys = append(mapKeys, mapKey)
+		}
+		sort.Strings(mapKeys)
+		for _, mapKey := range mapKeys {
+			if s, ok := typed[mapKey].(string); ok && len(s) > 0 {
+				values = append(values, &attributeValue{path: path.Index(cty.StringVal(mapKey)), key: key + "." + mapKey, value: s})
+			}
+		}
+	}
+	return values
+}
+
+func collectElem(values []*attributeValue, path cty.Path, key string, sch *schema.Schema, elem any) []*attributeValue {
+	res, ok := sch.Elem.(*schema.Resource)
+	if !ok {
+		return collectValues(values, path, key, sch, elem)
+	}
+	m, ok := elem.(map[string]any)
+	if !ok {
+		return values
+	}
+	attrNames := make([]string, 0, len(res.Schema))
+	for attrName := range res.Schema {
+		attrNames = append(attrNames, attrName)
+	}
+	sort.Strings(attrNames)
+	for _, attrName := range attrNames {
+		values = collectValues(values, path.GetAttr(attrName), key+"."+attrName, res.Schema[attrName], m[attrName])
+	}
+	return values
+}
+
+// resolveReferences returns a message describing the references within the given value which couldn't get resolved.
+// References which can't get checked, e.g. because of missing permissions, are considered to be valid
+func resolveReferences(ctx context.Context, credentials *settings.Credentials, references []export.Reference, value *attributeValue) string {
+	// IDs of several kinds of configuration may look alike - one of them containing the ID is sufficient
+	candidates := []export.ResourceType{}
+	resolved := false
+	messages := []string{}
+	for _, reference := range references {
+		if reference.Attribute != nil && !reference.Attribute.MatchString(value.key) {
+			continue
+		}
+		if len(reference.EntityType) > 0 {
+			for _, entityID := range reference.Pattern.FindAllString(value.value, -1) {
+				if exists, ok := lookups.entity(ctx, credentials, entityID); ok && !exists {
+					messages = append(messages, fmt.Sprintf("no monitored entity with ID `%s` exists", entityID))
+				}
+			}
+			continue
+		}
+		if !reference.Pattern.MatchString(value.value) {
+			continue
+		}
+		stubs, ok := lookups.stubs(ctx, credentials, reference.ResourceType)
+		if !ok || stubs.contains(value.value, reference.ByName) {
+			resolved = true
+			continue
+		}
+		if reference.ByName {
+			messages = append(messages, fmt.Sprintf("no `%s` named `%s` exists", reference.ResourceType, value.value))
+			continue
+		}
+		candidates = append(candidates, reference.ResourceType)
+	}
+	if !resolved && len(candidates) > 0 {
+		names := []string{}
+		for _, candidate := range candidates {
+			names = append(names, fmt.Sprintf("`%s`", candidate))
+		}
+		messages = append(messages, fmt.Sprintf("`%s` isn't the ID of any existing %s", value.value, strings.Join(names, " or ")))
+	}
+	return strings.Join(messages, ", ")
+}
+
+type stubLookup struct {
+	once  sync.Once
+	stubs api.Stubs
+	ok    bool
+}
+
+func (me *stubLookup) contains(value string, byName bool) bool {
+	for _, stub := range me.stubs {
+		if stub.ID == value || (stub.LegacyID != nil && *stub.LegacyID == value) {
+			return true
+		}
+		if byName && stub.Name == value {
+			return true
+		}
+	}
+	return false
+}
+
+type entityLookup struct {
+	once   sync.Once
+	exists bool
+	ok     bool
+}
+
+// referenceLookups caches the results of resolving references for the lifetime of the provider process,
+// i.e. for a single plan or apply. Every configuration type gets listed at most once per environment
+type referenceLookups struct {
+	mu       sync.Mutex
+	stubMap  map[string]*stubLookup
+	entities map[string]*entityLookup
+}
+
+var lookups = &referenceLookups{stubMap: map[string]*stubLookup{}, entities: map[string]*entityLookup{}}
+
+func (me *referenceLookups) stubs(ctx context.Context, credentials *settings.Credentials, resourceType export.ResourceType) (*stubLookup, bool) {
+	me.mu.Lock()
+	key := credentials.URL + "|" + string(resourceType)
+	lookup, found := me.stubMap[key]
+	if !found {
+		lookup = &stubLookup{}
+		me.stubMap[key] = lookup
+	}
+	me.mu.Unlock()
+
+	lookup.once.Do(func() {
+		descriptor, found := export.AllResources[resourceType]
+		if !found {
+			return
+		}
+		stubs, err := descriptor.Service(credentials).List(ctx)
+		if err != nil {
+			logging.Debug.Warn.Printf("[REFERENCES] [%s] unable to list: %s", resourceType, err.Error())
+			return
+		}
+		lookup.stubs = stubs
+		lookup.ok = true
+	})
+	return lookup, lookup.ok
+}
+
+// entity returns whether the monitored entity with the given ID exists.
+// The second result is `false` if that couldn't get determined
+func (me *referenceLookups) entity(ctx context.Context, credentials *settings.Credentials, entityID string) (bool, bool) {
+	me.mu.Lock()
+	key := credentials.URL + "|" + entityID
+	lookup, found := me.entities[key]
+	if !found {
+		lookup = &entityLookup{}
+		me.entities[key] = lookup
+	}
+	me.mu.Unlock()
+
+	lookup.once.Do(func() {
+		var entity struct {
+			EntityID string `json:"entityId"`
+		}
+		client := rest.DefaultClient(credentials.URL, credentials.Token)
+		err := client.Get(fmt.Sprintf("/api/v2/entities/%s?fields=lastSeenTms", url.PathEscape(entityID)), 200).Finish(&entity)
+		if err == nil {
+			lookup.exists, lookup.ok = true, true
+			return
+		}
+		if restError, ok := err.(rest.Error); ok && (restError.Code == 404 || restError.Code == 400) {
+			lookup.exists, lookup.ok = false, true
+			return
+		}
+		logging.Debug.Warn.Printf("[REFERENCES] [%s] unable to look up entity: %s", entityID, err.Error())
+	})
+	return lookup.exists, lookup.ok
+}
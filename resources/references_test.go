@@ -0,0 +1,95 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/testing/fake"
+)
+
+func createSettingsObject(t *testing.T, tenant *fake.Tenant, schemaID string, value any) string {
+	t.Helper()
+	data, err := json.Marshal([]map[string]any{{"schemaId": schemaID, "scope": "environment", "value": value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, tenant.URL()+"/api/v2/settings/objects", bytes.NewBuffer(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Api-Token "+fake.Token)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var responses []struct {
+		ObjectID string `json:"objectId"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&responses); err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 1 || len(responses[0].ObjectID) == 0 {
+		t.Fatalf("unable to create settings object of schema `%s`", schemaID)
+	}
+	return responses[0].ObjectID
+}
+
+func TestResolveReferences(t *testing.T) {
+	tenant := fake.NewTenant()
+	defer tenant.Close()
+	credentials := &settings.Credentials{URL: tenant.URL(), Token: fake.Token}
+	existingZone := createSettingsObject(t, tenant, "builtin:management-zones", map[string]any{"name": "team-a"})
+	existingRule := createSettingsObject(t, tenant, "builtin:failure-detection.environment.rules", map[string]any{"name": "rule-a"})
+	unknownZone := "vu9U3hXa3q0AAAABABhidWlsdGluOm1hbmFnZW1lbnQtem9uZXMAAAAA"
+	if !strings.HasPrefix(existingZone, "vu9U3hXa3q0AAAAB") {
+		t.Fatalf("expected the fake environment to issue object IDs like real environments do, got `%s`", existingZone)
+	}
+
+	references := export.AllResources[export.ResourceTypes.FailureDetectionRules].References()
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		expected string
+	}{
+		{"existing management zone", "management_zones", existingZone, ""},
+		{"unknown management zone", "management_zones", unknownZone, "`" + unknownZone + "` isn't the ID of any existing `dynatrace_management_zone_v2`"},
+		{"unknown legacy ID of a management zone", "conditions.0.management_zones", "1234567890123", "`1234567890123` isn't the ID of any existing `dynatrace_management_zone_v2`"},
+		{"ordering", "insert_after", existingRule, ""},
+		{"id within unrelated attribute", "name", unknownZone, ""},
+		{"number within unrelated attribute", "description", "1234567890123", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message := resolveReferences(context.Background(), credentials, references, &attributeValue{key: test.key, value: test.value})
+			if message != test.expected {
+				t.Errorf("expected `%s`, got `%s`", test.expected, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package settingsobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object"
+	objsettings "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api/builtin/generic/object/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/resources"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource manages a settings object of an arbitrary Settings 2.0 schema.
+// The properties are getting converted and validated based on the schema bundled with the provider
+// or - in case the schema isn't known to the provider - the schema offered by the environment.
+func Resource() *schema.Resource {
+	sch := new(objsettings.Settings).Schema()
+	sch[resources.EnvironmentAttribute] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The name of one of the `environments` configured for the provider this resource should be managed in. Defaults to the environment configured for the provider itself",
+	}
+	return &schema.Resource{
+		Schema:        sch,
+		CreateContext: logging.Enable(Create),
+		UpdateContext: logging.Enable(Update),
+		ReadContext:   logging.Enable(Read),
+		DeleteContext: logging.Enable(Delete),
+		CustomizeDiff: CustomizeDiff,
+		Importer:      &schema.ResourceImporter{StateContext: resources.ImportStateEnvironment},
+	}
+}
+
+func credentials(d config.Getter, m any, credentialValidation int) (*settings.Credentials, error) {
+	environment, _ := d.Get(resources.EnvironmentAttribute).(string)
+	return config.EnvironmentCredentials(m, environment, credentialValidation)
+}
+
+// properties lists the names of the properties configured via `value` and `secrets`
+func properties(v *objsettings.Settings) []string {
+	result := []string{}
+	for key := range v.Value {
+		result = append(result, key)
+	}
+	for key := range v.Secrets {
+		result = append(result, key)
+	}
+	return result
+}
+
+// encode converts the configured properties into the typed settings object
+func encode(creds *settings.Credentials, v *objsettings.Settings) error {
+	sch, err := object.LoadSchema(creds, v.SchemaID, properties(v)...)
+	if err != nil {
+		return err
+	}
+	if violations := object.Encode(sch, v); len(violations) > 0 {
+		messages := []string{}
+		for _, violation := range violations {
+			messages = append(messages, violation.Error())
+		}
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+func Create(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := credentials(d, m, config.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	v := new(objsettings.Settings)
+	if err := v.UnmarshalHCL(hcl.DecoderFrom(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := encode(creds, v); err != nil {
+		return diag.FromErr(err)
+	}
+	stub, err := object.Service(creds).Create(ctx, v)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(stub.ID)
+	return Read(ctx, d, m)
+}
+
+func Update(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := credentials(d, m, config.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	v := new(objsettings.Settings)
+	if err := v.UnmarshalHCL(hcl.DecoderFrom(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	if !d.HasChange("insert_after") {
+		v.InsertAfter = ""
+	}
+	if err := encode(creds, v); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := object.Service(creds).Update(ctx, d.Id(), v); err != nil {
+		return diag.FromErr(err)
+	}
+	return Read(ctx, d, m)
+}
+
+func Read(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := credentials(d, m, config.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	state := new(objsettings.Settings)
+	if err := state.UnmarshalHCL(hcl.DecoderFrom(d)); err != nil {
+		return diag.FromErr(err)
+	}
+	v := &objsettings.Settings{SchemaID: state.SchemaID}
+	if err := object.Service(creds).Get(ctx, d.Id(), v); err != nil {
+		if restError, ok := err.(rest.Error); ok && restError.Code == 404 {
+			d.SetId("")
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+	keys := []string{}
+	for key := range v.Object {
+		keys = append(keys, key)
+	}
+	sch, err := object.LoadSchema(creds, v.SchemaID, keys...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	object.Decode(sch, v, state)
+	if !sch.Ordered {
+		v.InsertAfter = ""
+	}
+
+	marshalled := hcl.Properties{}
+	if err := v.MarshalHCL(marshalled); err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range marshalled {
+		d.Set(k, v)
+	}
+	return diag.Diagnostics{}
+}
+
+func Delete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	creds, err := credentials(d, m, config.CredValDefault)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := object.Service(creds).Delete(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.Diagnostics{}
+}
+
+// CustomizeDiff converts the configured properties according to the schema during plan and validates them.
+// Problems are reported at the entry of `value` or `secrets` they have been caused by.
+// Entries not known until apply are getting ignored.
+func CustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, m any) error {
+	if len(rd.Id()) > 0 && len(rd.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if !rd.NewValueKnown("schema_id") || !rd.NewValueKnown("value") || !rd.NewValueKnown("secrets") {
+		return nil
+	}
+	v := new(objsettings.Settings)
+	if err := v.UnmarshalHCL(hcl.DecoderFrom(rd)); err != nil {
+		logging.Debug.Warn.Printf("[VALIDATE] [%s] unable to validate planned settings: %s", v.SchemaID, err.Error())
+		return nil
+	}
+	for attr, entries := range map[string]map[string]string{"value": v.Value, "secrets": v.Secrets} {
+		for key := range entries {
+			if !rd.NewValueKnown(attr + "." + key) {
+				delete(entries, key)
+			}
+		}
+	}
+
+	creds, err := credentials(rd, m, config.CredValNone)
+	if err != nil {
+		creds = nil
+	}
+	sch, err := object.LoadSchema(creds, v.SchemaID, properties(v)...)
+	if err != nil {
+		return cty.GetAttrPath("schema_id").NewError(err)
+	}
+	if v.Scope == "environment" && len(sch.AllowedScopes) > 0 && !contains(sch.AllowedScopes, "environment") {
+		return cty.GetAttrPath("scope").NewError(fmt.Errorf("the schema `%s` doesn't allow for settings objects on environment level. Allowed scopes: %s", v.SchemaID, strings.Join(sch.AllowedScopes, ", ")))
+	}
+	violations := object.Encode(sch, v)
+	if len(violations) == 0 && validation.ENABLED {
+		for _, violation := range sch.Validate(v.Object) {
+			attr := "value"
+			if len(violation.Path) > 0 {
+				if _, found := v.Secrets[fmt.Sprint(violation.Path[0])]; found {
+					attr = "secrets"
+				}
+			}
+			violations = append(violations, &validation.Violation{Path: append(validation.Path{attr}, violation.Path...), Message: violation.Message})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path.String() < violations[j].Path.String() })
+	messages := []string{}
+	for _, violation := range violations {
+		messages = append(messages, violation.Error())
+	}
+	path := cty.GetAttrPath(fmt.Sprint(violations[0].Path[0]))
+	if len(violations[0].Path) > 1 {
+		path = path.Index(cty.StringVal(fmt.Sprint(violations[0].Path[1])))
+	}
+	return path.NewError(errors.New(strings.Join(messages, "\n")))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/rest"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/config"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateOnly sends the settings of a resource getting created (empty ID) or modified to the validation
+// endpoint of its service instead of persisting them. Services which don't declare to validate server side
+// (see `settings.ServerSideValidator`) result in an error.
+// Terraform records every resource which has been applied without errors in its state. Because nothing
+// has been persisted, also settings the environment considers to be valid are getting reported as an error.
+func (me *Generic) validateOnly(ctx context.Context, service settings.CRUDService[settings.Settings], id string, sttngs settings.Settings) diag.Diagnostics {
+	// validators without a counterpart within the Dynatrace environment would confirm anything
+	if !settings.ValidatesServerSide(service) {
+		return validateOnlyUnsupported(string(me.Type))
+	}
+	var err error
+	if len(id) == 0 {
+		validator, ok := service.(settings.Validator[settings.Settings])
+		if !ok {
+			return validateOnlyUnsupported(string(me.Type))
+		}
+		err = validator.Validate(sttngs)
+	} else {
+		validator, ok := service.(settings.UpdateValidator[settings.Settings])
+		if !ok {
+			return validateOnlyUnsupported(string(me.Type))
+		}
+		err = validator.ValidateUpdate(ctx, id, sttngs)
+	}
+	if err != nil {
+		if restError, ok := err.(rest.Error); ok {
+			vm := restError.ViolationMessage()
+			if len(vm) > 0 {
+				return diag.FromErr(errors.New(vm))
+			}
+			return diag.FromErr(errors.New(restError.Message))
+		}
+		return diag.FromErr(err)
+	}
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("The settings for `%s` are valid, but haven't been applied", me.Type),
+		Detail:   "The provider has been configured with `validate_only`. The Dynatrace environment accepted the settings, but nothing has been persisted.",
+	}}
+}
+
+func validateOnlyUnsupported(resourceType string) diag.Diagnostics {
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("`%s` doesn't support `validate_only`", resourceType),
+		Detail:   "The provider has been configured with `validate_only`. The settings of this resource can't get validated by the Dynatrace environment without persisting them, hence nothing has been applied.",
+	}}
+}
+
+func validateOnlyNoDelete(resourceType string) diag.Diagnostics {
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("`%s` hasn't been deleted", resourceType),
+		Detail:   "The provider has been configured with `validate_only`. Resources are never getting deleted in this mode.",
+	}}
+}
+
+// ValidateOnlyGuard protects resources which are able to validate their settings only by persisting them.
+// While the provider is configured with `validate_only` creating, modifying or deleting such a resource
+// results in an error instead.
+func ValidateOnlyGuard(resourceType string, resource *schema.Resource) *schema.Resource {
+	guard := func(fn func(context.Context, *schema.ResourceData, any) diag.Diagnostics, diags func(string) diag.Diagnostics) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+		if fn == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			if config.ValidateOnly(m) {
+				return diags(resourceType)
+			}
+			return fn(ctx, d, m)
+		}
+	}
+	resource.CreateContext = guard(resource.CreateContext, validateOnlyUnsupported)
+	resource.UpdateContext = guard(resource.UpdateContext, validateOnlyUnsupported)
+	resource.DeleteContext = guard(resource.DeleteContext, validateOnlyNoDelete)
+	return resource
+}
@@ -0,0 +1,102 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/api"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/export"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type validateOnlySettings struct{}
+
+func (me *validateOnlySettings) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+func (me *validateOnlySettings) MarshalHCL(hcl.Properties) error        { return nil }
+func (me *validateOnlySettings) UnmarshalHCL(decoder hcl.Decoder) error { return nil }
+
+// noopValidatorService has a `Validate` which confirms anything, like services without a validation endpoint
+type noopValidatorService struct {
+	validated int
+}
+
+func (me *noopValidatorService) List(ctx context.Context) (api.Stubs, error) { return nil, nil }
+func (me *noopValidatorService) Get(ctx context.Context, id string, v *validateOnlySettings) error {
+	return nil
+}
+func (me *noopValidatorService) SchemaID() string { return "fake:validate-only" }
+func (me *noopValidatorService) Create(ctx context.Context, v *validateOnlySettings) (*api.Stub, error) {
+	return nil, nil
+}
+func (me *noopValidatorService) Update(ctx context.Context, id string, v *validateOnlySettings) error {
+	return nil
+}
+func (me *noopValidatorService) Delete(ctx context.Context, id string) error { return nil }
+func (me *noopValidatorService) Validate(v *validateOnlySettings) error {
+	me.validated++
+	return nil
+}
+
+// serverSideValidatorService declares to validate the settings within the Dynatrace environment
+type serverSideValidatorService struct {
+	settings.ValidatorEndpoint
+	noopValidatorService
+}
+
+func TestDefaultServiceValidatesServerSide(t *testing.T) {
+	credentials := &settings.Credentials{URL: "https://abc12345.live.dynatrace.com"}
+	if !settings.ValidatesServerSide(settings.NewCRUDService[*validateOnlySettings](credentials, "fake:validate-only", nil)) {
+		t.Error("expected a service without options to use the validator endpoint")
+	}
+	if settings.ValidatesServerSide(settings.NewCRUDService(credentials, "fake:validate-only", &settings.ServiceOptions[*validateOnlySettings]{HasNoValidator: true})) {
+		t.Error("expected a service without validator endpoint not to validate server side")
+	}
+}
+
+func TestValidateOnly(t *testing.T) {
+	generic := &Generic{Type: export.ResourceTypes.Alerting}
+
+	noop := &noopValidatorService{}
+	for _, id := range []string{"", "existing-id"} {
+		diags := generic.validateOnly(context.Background(), &settings.GenericCRUDService[*validateOnlySettings]{Service: noop}, id, &validateOnlySettings{})
+		if len(diags) != 1 || !strings.Contains(diags[0].Summary, "doesn't support `validate_only`") {
+			t.Errorf("expected a service validating nothing to be reported as unsupported, actual: %v", diags)
+		}
+	}
+	if noop.validated > 0 {
+		t.Error("expected the no-op validator not to get invoked")
+	}
+
+	serverSide := &serverSideValidatorService{}
+	for _, id := range []string{"", "existing-id"} {
+		diags := generic.validateOnly(context.Background(), &settings.GenericCRUDService[*validateOnlySettings]{Service: serverSide}, id, &validateOnlySettings{})
+		if len(diags) != 1 || !strings.Contains(diags[0].Summary, "are valid, but haven't been applied") {
+			t.Errorf("expected settings validated server side to be reported as valid, actual: %v", diags)
+		}
+	}
+	if serverSide.validated != 2 {
+		t.Errorf("expected the validator to get invoked twice, actual: %d", serverSide.validated)
+	}
+}
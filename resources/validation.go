@@ -0,0 +1,183 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/provider/logging"
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiff combines the `CustomizeDiff` function of the settings (if there is one)
+// with the validation of the planned settings against the bundled Settings 2.0 schema
+// and the resolution of the references they contain, if enabled.
+// The attribute schemata of the resource are required for reporting violations at the attributes causing them
+func (me *Generic) customizeDiff(stngs settings.Settings, schemata map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	var customizeDiff schema.CustomizeDiffFunc
+	if dc, ok := stngs.(DiffCustomizer); ok {
+		customizeDiff = dc.CustomizeDiff
+	}
+	return func(ctx context.Context, rd *schema.ResourceDiff, m any) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, rd, m); err != nil {
+				return err
+			}
+		}
+		if err := me.validateSchema(rd, m, schemata); err != nil {
+			return err
+		}
+		return me.validateReferences(ctx, rd, m, schemata)
+	}
+}
+
+// validateSchema checks the planned settings against the bundled schema and reports the violations at the
+// attribute they have been caused by. Violations caused by values not known until apply are getting ignored.
+func (me *Generic) validateSchema(rd *schema.ResourceDiff, m any, schemata map[string]*schema.Schema) error {
+	if !validation.ENABLED {
+		return nil
+	}
+	// resources which aren't getting created or modified are not of interest
+	if len(rd.Id()) > 0 && len(rd.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	credentials, diags := me.credentials(rd, m)
+	if len(diags) > 0 {
+		credentials = &settings.Credentials{}
+	}
+	schemaID := me.Descriptor.Service(credentials).SchemaID()
+	if sch, err := validation.Load(schemaID); err != nil || sch == nil {
+		return nil
+	}
+	sttngs := me.Settings()
+	if err := hcl.UnmarshalHCL(sttngs, hcl.DecoderFrom(rd)); err != nil {
+		logging.Debug.Warn.Printf("[VALIDATE] [%s] unable to validate planned settings: %s", me.Type, err.Error())
+		return nil
+	}
+	violations, err := validation.Validate(schemaID, sttngs)
+	if err != nil {
+		logging.Debug.Warn.Printf("[VALIDATE] [%s] unable to validate planned settings: %s", me.Type, err.Error())
+		return nil
+	}
+
+	var path cty.Path
+	messages := []string{}
+	for _, violation := range violations {
+		attrPath, key := attributePath(schemata, violation.Path)
+		if len(key) > 0 && !rd.NewValueKnown(key) {
+			continue
+		}
+		if len(messages) == 0 {
+			path = attrPath
+		}
+		if len(key) > 0 {
+			messages = append(messages, fmt.Sprintf("%s: %s", key, violation.Message))
+		} else {
+			messages = append(messages, violation.Error())
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return path.NewError(errors.New(strings.Join(messages, "\n")))
+}
+
+// attributePath resolves the attribute of the given resource schema a property of a settings object is stored in.
+// Lists of objects are in most cases getting represented as a block containing a repeated nested block.
+// The resolution stops at sets, because their elements can't get addressed via index.
+func attributePath(schemata map[string]*schema.Schema, path validation.Path) (cty.Path, string) {
+	attrPath := cty.Path{}
+	keys := []string{}
+	for i := 0; i < len(path); i++ {
+		name, ok := path[i].(string)
+		if !ok {
+			break
+		}
+		attrName, sch := lookupAttribute(schemata, name)
+		if sch == nil {
+			break
+		}
+		attrPath = attrPath.GetAttr(attrName)
+		keys = append(keys, attrName)
+		res, isBlock := sch.Elem.(*schema.Resource)
+		if sch.Type == schema.TypeSet {
+			break
+		}
+		if sch.Type != schema.TypeList {
+			continue
+		}
+		if idx, ok := next(path, i); ok {
+			// a list of objects wrapped into a block containing only a single repeated block
+			if isBlock && sch.MaxItems == 1 && len(res.Schema) == 1 {
+				for wrappedName, wrapped := range res.Schema {
+					if wrapped.Type != schema.TypeList && wrapped.Type != schema.TypeSet {
+						break
+					}
+					attrPath = attrPath.IndexInt(0).GetAttr(wrappedName)
+					keys = append(keys, "0", wrappedName)
+					sch = wrapped
+					res, isBlock = wrapped.Elem.(*schema.Resource)
+				}
+				if sch.Type == schema.TypeSet {
+					break
+				}
+			}
+			attrPath = attrPath.IndexInt(idx)
+			keys = append(keys, strconv.Itoa(idx))
+			i++
+		} else if isBlock {
+			// a single nested object
+			attrPath = attrPath.IndexInt(0)
+			keys = append(keys, "0")
+		}
+		if !isBlock {
+			break
+		}
+		schemata = res.Schema
+	}
+	return attrPath, strings.Join(keys, ".")
+}
+
+func next(path validation.Path, i int) (int, bool) {
+	if i+1 >= len(path) {
+		return 0, false
+	}
+	idx, ok := path[i+1].(int)
+	return idx, ok
+}
+
+// lookupAttribute finds the attribute for a property of a settings object.
+// Attribute names are usually the snake case variant of the property name
+func lookupAttribute(schemata map[string]*schema.Schema, name string) (string, *schema.Schema) {
+	normalized := strings.ToLower(name)
+	for attrName, sch := range schemata {
+		if strings.ReplaceAll(attrName, "_", "") == normalized {
+			return attrName, sch
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,74 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAttributePath(t *testing.T) {
+	schemata := map[string]*schema.Schema{
+		"display_name": {Type: schema.TypeString, Optional: true},
+		"tags":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"values":       {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"options": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"max_count": {Type: schema.TypeInt, Optional: true},
+		}}},
+		"rules": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"rule": {Type: schema.TypeList, Required: true, MinItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"pattern": {Type: schema.TypeString, Optional: true},
+			}}},
+		}}},
+		"conditions": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"condition": {Type: schema.TypeSet, Required: true, MinItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"key": {Type: schema.TypeString, Optional: true},
+			}}},
+		}}},
+	}
+
+	tests := []struct {
+		name     string
+		path     validation.Path
+		expected cty.Path
+		key      string
+	}{
+		{"empty", validation.Path{}, cty.Path{}, ""},
+		{"primitive", validation.Path{"displayName"}, cty.GetAttrPath("display_name"), "display_name"},
+		{"unknown property", validation.Path{"somethingElse"}, cty.Path{}, ""},
+		{"set element", validation.Path{"tags", 1}, cty.GetAttrPath("tags"), "tags"},
+		{"list element", validation.Path{"values", 2}, cty.GetAttrPath("values").IndexInt(2), "values.2"},
+		{"nested object", validation.Path{"options", "maxCount"}, cty.GetAttrPath("options").IndexInt(0).GetAttr("max_count"), "options.0.max_count"},
+		{"wrapped list", validation.Path{"rules", 3, "pattern"}, cty.GetAttrPath("rules").IndexInt(0).GetAttr("rule").IndexInt(3).GetAttr("pattern"), "rules.0.rule.3.pattern"},
+		{"wrapped set", validation.Path{"conditions", 3, "key"}, cty.GetAttrPath("conditions").IndexInt(0).GetAttr("condition"), "conditions.0.condition"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, key := attributePath(schemata, test.path)
+			if key != test.key {
+				t.Errorf("expected key `%s`, got `%s`", test.key, key)
+			}
+			if !path.Equals(test.expected) {
+				t.Errorf("expected path %#v, got %#v", test.expected, path)
+			}
+		})
+	}
+}
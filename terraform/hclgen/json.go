@@ -0,0 +1,329 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package hclgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl2 "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// JSONCommentKey is the property Terraform ignores within objects of the JSON syntax.
+// Comments preceding a block in native syntax are getting preserved with it.
+const JSONCommentKey = "//"
+
+// ToJSON converts configuration in native HCL syntax into Terraform JSON syntax (`.tf.json`).
+//
+// Literal values are written as JSON values. Any other expression (references, function calls, ...)
+// is written as a string containing a template interpolation (`"${var.x.id}"`).
+// Literal occurrences of `${` and `%{` within strings are getting escaped.
+func ToJSON(src []byte, filename string) ([]byte, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl2.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unable to convert `%s`", filename)
+	}
+	lines := strings.Split(string(src), "\n")
+	root := &jsonObject{}
+	for _, item := range bodyItems(body) {
+		switch typed := item.(type) {
+		case *hclsyntax.Attribute:
+			root.Set(typed.Name, exprToJSON(typed.Expr, src, ""))
+		case *hclsyntax.Block:
+			content := blockToJSON(typed, src, "")
+			if comment := precedingComments(lines, typed.TypeRange.Start.Line); len(comment) > 0 {
+				content.SetFirst(JSONCommentKey, comment)
+			}
+			if len(typed.Labels) == 0 {
+				root.Add(typed.Type, content)
+				continue
+			}
+			parent := root.Object(typed.Type)
+			for _, label := range typed.Labels[:len(typed.Labels)-1] {
+				parent = parent.Object(label)
+			}
+			parent.Add(typed.Labels[len(typed.Labels)-1], content)
+		}
+	}
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bodyItems returns the attributes and blocks of a body in the order they are appearing in the source
+func bodyItems(body *hclsyntax.Body) []any {
+	type positioned struct {
+		offset int
+		item   any
+	}
+	items := []positioned{}
+	for _, attr := range body.Attributes {
+		items = append(items, positioned{attr.SrcRange.Start.Byte, attr})
+	}
+	for _, block := range body.Blocks {
+		items = append(items, positioned{block.TypeRange.Start.Byte, block})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].offset < items[j].offset })
+	result := []any{}
+	for _, item := range items {
+		result = append(result, item.item)
+	}
+	return result
+}
+
+// precedingComments collects the `#` comments directly above the given line (1-based)
+func precedingComments(lines []string, line int) string {
+	comments := []string{}
+	for idx := line - 2; idx >= 0; idx-- {
+		trimmed := strings.TrimSpace(lines[idx])
+		if !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		comments = append([]string{strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))}, comments...)
+	}
+	return strings.TrimSpace(strings.Join(comments, "\n"))
+}
+
+func blockToJSON(block *hclsyntax.Block, src []byte, path string) *jsonObject {
+	if len(path) == 0 {
+		path = block.Type
+	} else {
+		path = path + "." + block.Type
+	}
+	content := &jsonObject{}
+	for _, item := range bodyItems(block.Body) {
+		switch typed := item.(type) {
+		case *hclsyntax.Attribute:
+			content.Set(typed.Name, exprToJSON(typed.Expr, src, path+"."+typed.Name))
+		case *hclsyntax.Block:
+			// nested blocks are always getting represented as lists of objects
+			var blocks []any
+			if stored, found := content.Get(typed.Type); found {
+				blocks, _ = stored.([]any)
+			}
+			content.Set(typed.Type, append(blocks, blockToJSON(typed, src, path)))
+		}
+	}
+	return content
+}
+
+// Meta arguments, which in JSON syntax expect references as plain strings rather than interpolations
+func isRawReferenceArgument(path string) bool {
+	return strings.HasSuffix(path, "lifecycle.ignore_changes") ||
+		strings.HasSuffix(path, "lifecycle.replace_triggered_by") ||
+		strings.HasSuffix(path, ".depends_on") ||
+		path == "variable.type"
+}
+
+func exprToJSON(expr hclsyntax.Expression, src []byte, path string) any {
+	if isRawReferenceArgument(path) {
+		if tuple, ok := expr.(*hclsyntax.TupleConsExpr); ok {
+			result := []any{}
+			for _, elem := range tuple.Exprs {
+				if value, diags := elem.Value(nil); !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+					result = append(result, value.AsString())
+				} else {
+					result = append(result, sourceText(elem, src))
+				}
+			}
+			return result
+		}
+		return sourceText(expr, src)
+	}
+	switch typed := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		var sb strings.Builder
+		for _, part := range typed.Parts {
+			if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok && literal.Val.Type() == cty.String {
+				sb.WriteString(escapeTemplate(literal.Val.AsString()))
+			} else {
+				sb.WriteString("${" + sourceText(part, src) + "}")
+			}
+		}
+		return sb.String()
+	case *hclsyntax.TemplateWrapExpr:
+		return "${" + sourceText(typed.Wrapped, src) + "}"
+	case *hclsyntax.TupleConsExpr:
+		result := []any{}
+		for _, elem := range typed.Exprs {
+			result = append(result, exprToJSON(elem, src, ""))
+		}
+		return result
+	case *hclsyntax.ObjectConsExpr:
+		result := &jsonObject{}
+		for _, item := range typed.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.Type() != cty.String || !key.IsKnown() || key.IsNull() {
+				result.Set("${"+sourceText(item.KeyExpr, src)+"}", exprToJSON(item.ValueExpr, src, ""))
+				continue
+			}
+			result.Set(key.AsString(), exprToJSON(item.ValueExpr, src, ""))
+		}
+		return result
+	}
+	if len(expr.Variables()) == 0 {
+		if value, diags := expr.Value(nil); !diags.HasErrors() && value.IsWhollyKnown() {
+			return ctyToJSON(value)
+		}
+	}
+	return "${" + sourceText(expr, src) + "}"
+}
+
+func ctyToJSON(value cty.Value) any {
+	if value.IsNull() {
+		return nil
+	}
+	valueType := value.Type()
+	switch {
+	case valueType == cty.String:
+		return escapeTemplate(value.AsString())
+	case valueType == cty.Number:
+		return json.Number(value.AsBigFloat().Text('f', -1))
+	case valueType == cty.Bool:
+		return value.True()
+	case valueType.IsListType() || valueType.IsTupleType() || valueType.IsSetType():
+		result := []any{}
+		for it := value.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			result = append(result, ctyToJSON(elem))
+		}
+		return result
+	case valueType.IsMapType() || valueType.IsObjectType():
+		result := &jsonObject{}
+		for it := value.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			result.Set(key.AsString(), ctyToJSON(elem))
+		}
+		return result
+	}
+	return nil
+}
+
+func escapeTemplate(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
+
+func sourceText(expr hclsyntax.Expression, src []byte) string {
+	return strings.TrimSpace(string(expr.Range().SliceBytes(src)))
+}
+
+// jsonObject is a JSON object, which keeps its properties in the order they have been added
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (me *jsonObject) Get(key string) (any, bool) {
+	value, found := me.values[key]
+	return value, found
+}
+
+func (me *jsonObject) Set(key string, value any) {
+	if me.values == nil {
+		me.values = map[string]any{}
+	}
+	if _, found := me.values[key]; !found {
+		me.keys = append(me.keys, key)
+	}
+	me.values[key] = value
+}
+
+func (me *jsonObject) SetFirst(key string, value any) {
+	me.Set(key, value)
+	keys := []string{key}
+	for _, k := range me.keys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	me.keys = keys
+}
+
+// Add stores the value. A value stored already under the same key turns into a list.
+func (me *jsonObject) Add(key string, value any) {
+	stored, found := me.Get(key)
+	if !found {
+		me.Set(key, value)
+		return
+	}
+	if list, ok := stored.([]any); ok {
+		me.Set(key, append(list, value))
+		return
+	}
+	me.Set(key, []any{stored, value})
+}
+
+// Object returns the object stored under the given key, creating it if necessary
+func (me *jsonObject) Object(key string) *jsonObject {
+	if stored, found := me.Get(key); found {
+		if object, ok := stored.(*jsonObject); ok {
+			return object
+		}
+	}
+	object := &jsonObject{}
+	me.Set(key, object)
+	return object
+}
+
+func (me *jsonObject) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("{")
+	for idx, key := range me.keys {
+		if idx > 0 {
+			buf.WriteString(",")
+		}
+		keyData, err := marshalJSON(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := marshalJSON(me.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyData)
+		buf.WriteString(":")
+		buf.Write(valueData)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+func marshalJSON(v any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
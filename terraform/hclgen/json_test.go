@@ -0,0 +1,71 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package hclgen_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hclgen"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected `.tf.json` files within `testdata/json`")
+
+// TestToJSON converts every `.tf` file within `testdata/json` and compares the result
+// with the `.tf.json` file of the same name
+func TestToJSON(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "json", "*.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no test cases found")
+	}
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".tf"), func(t *testing.T) {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := hclgen.ToJSON(src, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, diags := hcljson.Parse(actual, file+".json"); diags.HasErrors() {
+				t.Fatalf("the result isn't valid JSON syntax: %s", diags.Error())
+			}
+			if *update {
+				if err := os.WriteFile(file+".json", actual, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			expected, err := os.ReadFile(file + ".json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(actual) != string(expected) {
+				t.Errorf("expected\n%s\nactual\n%s", string(expected), string(actual))
+			}
+		})
+	}
+}
@@ -32,6 +32,11 @@ import (
 
 var preventHeredoc = os.Getenv("DYNATRACE_HEREDOC") == "false"
 
+// SetHeredoc overrides whether multi line strings are allowed to get rendered as heredoc
+func SetHeredoc(enabled bool) {
+	preventHeredoc = !enabled
+}
+
 type primitiveEntry struct {
 	Indent      string
 	Key         string
@@ -0,0 +1,13 @@
+# This file has been generated
+
+# ID vu9U3hXa3q0AAAABABhidWlsdGluOm1hbmFnZW1lbnQtem9uZXM
+# Owner: team-a
+resource "dynatrace_management_zone_v2" "zone" {
+  # inline comments are not preserved
+  name = "zone" # neither are trailing ones
+}
+
+// comments using slashes are not preserved
+resource "dynatrace_management_zone_v2" "other" {
+  name = "other"
+}
@@ -0,0 +1,18 @@
+resource "dynatrace_json_dashboard" "overview" {
+  contents = <<-EOT
+    {
+      "dashboardMetadata": {
+        "name": "Overview",
+        "owner": "${var.owner}"
+      },
+      "tiles": [ { "markdown": "cost: $${budget}" } ]
+    }
+  EOT
+}
+
+resource "dynatrace_log_processing" "rule" {
+  query      = "matchesValue(content, \"%%{literal}\")"
+  sample_log = <<EOT
+plain text
+EOT
+}
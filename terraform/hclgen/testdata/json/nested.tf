@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    dynatrace = {
+      source  = "dynatrace-oss/dynatrace"
+      version = "~> 1.0"
+    }
+  }
+}
+
+resource "dynatrace_management_zone_v2" "zone" {
+  name = "zone"
+  rules {
+    rule {
+      type    = "ME"
+      enabled = true
+      attribute_rule {
+        entity_type = "HOST"
+        attribute_conditions {
+          condition {
+            key      = "HOST_GROUP_NAME"
+            operator = "EQUALS"
+            string_value = "prod"
+          }
+          condition {
+            key      = "HOST_NAME"
+            operator = "BEGINS_WITH"
+            string_value = "web"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "dynatrace_management_zone_v2" "other" {
+  name = "other"
+}
+
+module "alerting" {
+  source = "./modules/alerting"
+}
@@ -0,0 +1,18 @@
+resource "dynatrace_alerting" "profile" {
+  name             = "team-${var.team}"
+  management_zone  = dynatrace_management_zone_v2.zone.legacy_id
+  enabled          = true
+  threshold        = 1.5
+  tags             = [ "env:prod", var.extra_tag ]
+  description      = upper(var.description)
+  depends_on       = [ dynatrace_management_zone_v2.zone ]
+
+  lifecycle {
+    ignore_changes = [ name, rules ]
+  }
+}
+
+variable "team" {
+  type    = string
+  default = "a"
+}
@@ -0,0 +1,389 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const header = `/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+`
+
+const (
+	importOpt    = "github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/opt"
+	importHCL    = "github.com/dynatrace-oss/terraform-provider-dynatrace/terraform/hcl"
+	importSchema = "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// goFile assembles a Go source file. Standard library imports are expected to come first,
+// separated by an empty string from the imports of other modules
+func goFile(pkg string, body string, imports ...string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package " + pkg + "\n\n")
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, imp := range imports {
+			buf.WriteString("\t" + imp + "\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.WriteString(body)
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %s\n%s", err.Error(), buf.String())
+	}
+	return source, nil
+}
+
+func (me *Model) EnumsFile(pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, enum := range me.Enums {
+		fmt.Fprintf(&buf, "type %s string\n\n", enum.Name)
+		fmt.Fprintf(&buf, "var %s = struct {\n", plural(enum.Name))
+		for _, value := range enum.Values {
+			fmt.Fprintf(&buf, "\t%s %s\n", value.Name, enum.Name)
+		}
+		buf.WriteString("}{\n")
+		for _, value := range enum.Values {
+			fmt.Fprintf(&buf, "\t%s,\n", strconv.Quote(value.Value))
+		}
+		buf.WriteString("}\n\n")
+	}
+	return goFile(pkg, buf.String())
+}
+
+func (me *Model) StructFile(pkg string, s *Struct) ([]byte, error) {
+	var buf bytes.Buffer
+	if s.Wrapper != nil {
+		me.writeWrapper(&buf, s)
+	}
+
+	fmt.Fprintf(&buf, "type %s struct {\n", s.Name)
+	for _, field := range s.Fields {
+		tag := field.Key
+		if field.OmitEmpty {
+			tag = tag + ",omitempty"
+		}
+		switch field.Name {
+		case "Scope":
+			if s.Root && field.Key == "" {
+				fmt.Fprintf(&buf, "\t%s %s `json:\"-\" scope:\"scope\"` // %s\n", field.Name, field.GoType, field.Description)
+				continue
+			}
+		case "InsertAfter":
+			if s.Root && field.Key == "" {
+				fmt.Fprintf(&buf, "\t%s %s `json:\"-\"`\n", field.Name, field.GoType)
+				continue
+			}
+		}
+		fmt.Fprintf(&buf, "\t%s %s `json:%s` // %s\n", field.Name, field.GoType, strconv.Quote(tag), field.Description)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(&buf, "func (me *%s) Schema() map[string]*schema.Schema {\n", s.Name)
+	buf.WriteString("\treturn map[string]*schema.Schema{\n")
+	for _, field := range s.Fields {
+		fmt.Fprintf(&buf, "\t\t%s: {\n", strconv.Quote(field.Attribute))
+		fmt.Fprintf(&buf, "\t\t\tType: %s,\n", field.TFType)
+		fmt.Fprintf(&buf, "\t\t\tDescription: %s,\n", strconv.Quote(field.Description))
+		switch {
+		case field.Optional && len(field.Reason) > 0:
+			fmt.Fprintf(&buf, "\t\t\tOptional: true, // %s\n", field.Reason)
+		case field.Optional:
+			buf.WriteString("\t\t\tOptional: true,\n")
+		default:
+			buf.WriteString("\t\t\tRequired: true,\n")
+		}
+		if len(field.Elem) > 0 {
+			fmt.Fprintf(&buf, "\t\t\tElem: %s,\n", field.Elem)
+		}
+		if field.Block {
+			buf.WriteString("\t\t\tMinItems: 1,\n")
+			buf.WriteString("\t\t\tMaxItems: 1,\n")
+		}
+		if field.Sensitive {
+			buf.WriteString("\t\t\tSensitive: true,\n")
+		}
+		if s.Root && field.Key == "" {
+			switch field.Name {
+			case "Scope":
+				if field.Optional {
+					buf.WriteString("\t\t\tDefault: \"environment\",\n")
+				}
+				buf.WriteString("\t\t\tForceNew: true,\n")
+			case "InsertAfter":
+				buf.WriteString("\t\t\tComputed: true,\n")
+			}
+		}
+		buf.WriteString("\t\t},\n")
+	}
+	buf.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(&buf, "func (me *%s) MarshalHCL(properties hcl.Properties) error {\n", s.Name)
+	buf.WriteString("\treturn properties.EncodeAll(map[string]any{\n")
+	for _, field := range s.Fields {
+		fmt.Fprintf(&buf, "\t\t%s: me.%s,\n", strconv.Quote(field.Attribute), field.Name)
+	}
+	buf.WriteString("\t})\n}\n\n")
+
+	imports := []string{}
+	if checks := strings.Join(me.preconditions(s), ""); len(checks) > 0 {
+		fmt.Fprintf(&buf, "func (me *%s) HandlePreconditions() error {\n", s.Name)
+		buf.WriteString(checks)
+		buf.WriteString("\treturn nil\n}\n\n")
+		for _, name := range []string{"fmt", "slices"} {
+			if strings.Contains(checks, name+".") {
+				imports = append(imports, strconv.Quote(name))
+			}
+		}
+		if len(imports) > 0 {
+			imports = append(imports, "")
+		}
+		if strings.Contains(checks, "opt.") {
+			imports = append(imports, strconv.Quote(importOpt))
+		}
+	}
+
+	fmt.Fprintf(&buf, "func (me *%s) UnmarshalHCL(decoder hcl.Decoder) error {\n", s.Name)
+	buf.WriteString("\treturn decoder.DecodeAll(map[string]any{\n")
+	for _, field := range s.Fields {
+		fmt.Fprintf(&buf, "\t\t%s: &me.%s,\n", strconv.Quote(field.Attribute), field.Name)
+	}
+	buf.WriteString("\t})\n}\n")
+
+	imports = append(imports, strconv.Quote(importHCL), strconv.Quote(importSchema))
+	return goFile(pkg, buf.String(), imports...)
+}
+
+func (me *Model) writeWrapper(buf *bytes.Buffer, s *Struct) {
+	tfType := "schema.TypeList"
+	if s.Wrapper.Set {
+		tfType = "schema.TypeSet"
+	}
+	fmt.Fprintf(buf, "type %s []*%s\n\n", s.Wrapper.Name, s.Name)
+	fmt.Fprintf(buf, "func (me *%s) Schema() map[string]*schema.Schema {\n", s.Wrapper.Name)
+	buf.WriteString("\treturn map[string]*schema.Schema{\n")
+	fmt.Fprintf(buf, "\t\t%s: {\n", strconv.Quote(s.Wrapper.Attribute))
+	fmt.Fprintf(buf, "\t\t\tType: %s,\n", tfType)
+	buf.WriteString("\t\t\tRequired: true,\n")
+	buf.WriteString("\t\t\tMinItems: 1,\n")
+	buf.WriteString("\t\t\tDescription: \"\",\n")
+	fmt.Fprintf(buf, "\t\t\tElem: &schema.Resource{Schema: new(%s).Schema()},\n", s.Name)
+	buf.WriteString("\t\t},\n\t}\n}\n\n")
+	fmt.Fprintf(buf, "func (me %s) MarshalHCL(properties hcl.Properties) error {\n", s.Wrapper.Name)
+	fmt.Fprintf(buf, "\treturn properties.EncodeSlice(%s, me)\n}\n\n", strconv.Quote(s.Wrapper.Attribute))
+	fmt.Fprintf(buf, "func (me *%s) UnmarshalHCL(decoder hcl.Decoder) error {\n", s.Wrapper.Name)
+	fmt.Fprintf(buf, "\treturn decoder.DecodeSlice(%s, me)\n}\n\n", strconv.Quote(s.Wrapper.Attribute))
+}
+
+// preconditions produces the body of `HandlePreconditions` for the given struct.
+// Properties which are not nullable but only relevant under a precondition either
+// receive their default value or produce an error if they haven't been specified.
+func (me *Model) preconditions(s *Struct) []string {
+	checks := []string{}
+	for _, field := range s.Fields {
+		if field.Precondition == nil || field.Property.Nullable {
+			continue
+		}
+		if strings.HasPrefix(field.GoType, "[]") || (field.Block && !strings.HasPrefix(field.GoType, "*")) {
+			// collections are allowed to be empty unless `minObjects` says otherwise
+			if field.Property.MinObjects == nil || *field.Property.MinObjects == 0 {
+				continue
+			}
+		}
+		cond, ok := condition(s, field.Precondition)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "WARNING: precondition of `%s.%s` cannot be translated - please implement `HandlePreconditions` manually\n", s.Name, field.Key)
+			continue
+		}
+		if len(field.Default) > 0 && string(field.Default) != "null" {
+			assignment, ok := defaultValue(field)
+			if !ok {
+				continue
+			}
+			checks = append(checks, fmt.Sprintf("\tif me.%s == nil && (%s) {\n%s\t}\n", field.Name, cond, assignment))
+			continue
+		}
+		message := fmt.Sprintf("'%s' must be specified", field.Attribute)
+		args := ""
+		if ref := lookup(s, field.Precondition.Property); ref != nil && (field.Precondition.Type == "EQUALS" || field.Precondition.Type == "IN") {
+			message = message + fmt.Sprintf(" if '%s' is set to '%%v'", ref.Attribute)
+			args = ", me." + ref.Name
+			if ref.Pointer {
+				args = ", *me." + ref.Name
+			}
+		}
+		checks = append(checks, fmt.Sprintf("\tif me.%s == nil && (%s) {\n\t\treturn fmt.Errorf(%s%s)\n\t}\n", field.Name, cond, strconv.Quote(message), args))
+	}
+	return checks
+}
+
+func lookup(s *Struct, key string) *Field {
+	for _, field := range s.Fields {
+		if field.Key == key && len(key) > 0 {
+			return field
+		}
+	}
+	return nil
+}
+
+func condition(s *Struct, precondition *Precondition) (string, bool) {
+	switch precondition.Type {
+	case "NOT":
+		if precondition.Precondition == nil {
+			return "", false
+		}
+		cond, ok := condition(s, precondition.Precondition)
+		return "!(" + cond + ")", ok
+	case "AND", "OR":
+		op := " && "
+		if precondition.Type == "OR" {
+			op = " || "
+		}
+		conds := []string{}
+		for _, p := range precondition.Preconditions {
+			cond, ok := condition(s, p)
+			if !ok {
+				return "", false
+			}
+			conds = append(conds, "("+cond+")")
+		}
+		if len(conds) == 0 {
+			return "", false
+		}
+		return strings.Join(conds, op), true
+	}
+	field := lookup(s, precondition.Property)
+	if field == nil {
+		return "", false
+	}
+	switch precondition.Type {
+	case "NULL":
+		if field.Pointer || strings.HasPrefix(field.GoType, "[]") || field.Block {
+			return "me." + field.Name + " == nil", true
+		}
+		return "false", true
+	case "EQUALS":
+		return equals(field, precondition.ExpectedValue)
+	case "IN":
+		strs := []string{}
+		conds := []string{}
+		for _, value := range precondition.ExpectedValues {
+			if str, ok := value.(string); ok {
+				strs = append(strs, strconv.Quote(str))
+				continue
+			}
+			cond, ok := equals(field, value)
+			if !ok {
+				return "", false
+			}
+			conds = append(conds, cond)
+		}
+		if len(strs) > 0 {
+			if len(conds) > 0 {
+				return "", false
+			}
+			if field.Pointer {
+				return fmt.Sprintf("me.%s != nil && slices.Contains([]string{%s}, string(*me.%s))", field.Name, strings.Join(strs, ", "), field.Name), true
+			}
+			return fmt.Sprintf("slices.Contains([]string{%s}, string(me.%s))", strings.Join(strs, ", "), field.Name), true
+		}
+		if len(conds) == 0 {
+			return "false", true
+		}
+		return strings.Join(conds, " || "), true
+	}
+	return "", false
+}
+
+func equals(field *Field, expected any) (string, bool) {
+	if field.Block || strings.HasPrefix(field.GoType, "[]") {
+		return "", false
+	}
+	value := "me." + field.Name
+	guard := ""
+	if field.Pointer {
+		value = "*me." + field.Name
+		guard = "me." + field.Name + " != nil && "
+	}
+	switch v := expected.(type) {
+	case string:
+		return fmt.Sprintf("%sstring(%s) == %s", guard, value, strconv.Quote(v)), true
+	case bool:
+		if v {
+			return guard + value, true
+		}
+		return guard + "!" + value, true
+	case float64:
+		return fmt.Sprintf("%s%s == %s", guard, value, strconv.FormatFloat(v, 'f', -1, 64)), true
+	}
+	return "", false
+}
+
+func defaultValue(field *Field) (string, bool) {
+	var value any
+	if err := json.Unmarshal(field.Default, &value); err != nil {
+		return "", false
+	}
+	switch field.GoType {
+	case "*bool":
+		if b, ok := value.(bool); ok {
+			return fmt.Sprintf("\t\tme.%s = opt.NewBool(%v)\n", field.Name, b), true
+		}
+	case "*int":
+		if f, ok := value.(float64); ok {
+			return fmt.Sprintf("\t\tme.%s = opt.NewInt(%d)\n", field.Name, int(f)), true
+		}
+	case "*float64":
+		if f, ok := value.(float64); ok {
+			return fmt.Sprintf("\t\tme.%s = opt.NewFloat64(%s)\n", field.Name, strconv.FormatFloat(f, 'f', -1, 64)), true
+		}
+	case "*string":
+		if s, ok := value.(string); ok {
+			return fmt.Sprintf("\t\tme.%s = opt.NewString(%s)\n", field.Name, strconv.Quote(s)), true
+		}
+	default:
+		if s, ok := value.(string); ok && field.Pointer {
+			return fmt.Sprintf("\t\tvalue := %s(%s)\n\t\tme.%s = &value\n", strings.TrimPrefix(field.GoType, "*"), strconv.Quote(s), field.Name), true
+		}
+	}
+	return "", false
+}
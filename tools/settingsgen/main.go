@@ -0,0 +1,177 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+// settingsgen scaffolds a Settings 2.0 resource based on the `schema.json` of a schema.
+//
+// It is meant to get invoked via `go generate` from within the folder containing the `schema.json`, e.g.
+//
+//	//go:generate go run github.com/dynatrace-oss/terraform-provider-dynatrace/tools/settingsgen -resource dynatrace_log_sensitive_data_masking
+//
+// The structs in the `settings` subfolder and `service.go` are getting regenerated on every invocation.
+// Tests, example configurations and the documentation template are only getting created if they don't exist yet.
+// Unless `-register=false` is specified the resource gets added to `export.ResourceTypes`, `export.AllResources` and the provider.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("settingsgen", flag.ContinueOnError)
+	schemaFile := flags.String("schema", "schema.json", "the schema.json to generate the resource for")
+	resourceName := flags.String("resource", "", "the name of the resource, e.g. dynatrace_log_sensitive_data_masking")
+	pkg := flags.String("package", "", "the name of the generated Go packages (default: the name of the folder containing the schema)")
+	typeName := flags.String("type", "", "the name of the entry in export.ResourceTypes (default: derived from the resource name)")
+	subcategory := flags.String("subcategory", "Settings", "the subcategory of the resource within the documentation")
+	register := flags.Bool("register", true, "registers the resource within the export package and the provider")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(*resourceName) == 0 {
+		return errors.New("-resource is required")
+	}
+	if !strings.HasPrefix(*resourceName, "dynatrace_") {
+		return fmt.Errorf("the resource name `%s` needs to start with `dynatrace_`", *resourceName)
+	}
+	shortName := strings.TrimPrefix(*resourceName, "dynatrace_")
+	if len(*typeName) == 0 {
+		*typeName = pascal(shortName)
+	}
+
+	schemaPath, err := filepath.Abs(*schemaFile)
+	if err != nil {
+		return err
+	}
+	schema, err := LoadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	folder := filepath.Dir(schemaPath)
+	if len(*pkg) == 0 {
+		*pkg = strings.ToLower(strings.Join(words(filepath.Base(folder)), ""))
+	}
+	root, err := findRoot(folder)
+	if err != nil {
+		return err
+	}
+	relFolder, err := filepath.Rel(root, folder)
+	if err != nil {
+		return err
+	}
+	relFolder = filepath.ToSlash(relFolder)
+	importPath := modulePath + "/" + relFolder
+
+	model, err := NewModel(schema)
+	if err != nil {
+		return err
+	}
+
+	settingsFolder := filepath.Join(folder, "settings")
+	if err := os.MkdirAll(settingsFolder, 0755); err != nil {
+		return err
+	}
+	for _, s := range model.Structs {
+		source, err := model.StructFile(*pkg, s)
+		if err != nil {
+			return err
+		}
+		if err := write(filepath.Join(settingsFolder, s.File), source, true); err != nil {
+			return err
+		}
+	}
+	if len(model.Enums) > 0 {
+		source, err := model.EnumsFile(*pkg)
+		if err != nil {
+			return err
+		}
+		if err := write(filepath.Join(settingsFolder, "enums.go"), source, true); err != nil {
+			return err
+		}
+	}
+	generateArgs := "-resource " + *resourceName
+	if *typeName != pascal(shortName) {
+		generateArgs += " -type " + *typeName
+	}
+	if *subcategory != "Settings" {
+		generateArgs += fmt.Sprintf(" -subcategory %q", *subcategory)
+	}
+	source, err := ServiceFile(*pkg, importPath, schema, generateArgs)
+	if err != nil {
+		return err
+	}
+	if err := write(filepath.Join(folder, "service.go"), source, true); err != nil {
+		return err
+	}
+	if source, err = ServiceTestFile(*pkg, *typeName); err != nil {
+		return err
+	}
+	if err := write(filepath.Join(folder, "service_test.go"), source, false); err != nil {
+		return err
+	}
+	if err := write(filepath.Join(folder, "testdata", "terraform", "example_a.tf"), model.ExampleFile(*resourceName), false); err != nil {
+		return err
+	}
+	if err := write(filepath.Join(root, "templates", "resources", shortName+".md.tmpl"), DocsTemplate(*resourceName, *subcategory, relFolder, schema), false); err != nil {
+		return err
+	}
+
+	if !*register {
+		return nil
+	}
+	return (&Registration{Root: root, ResourceName: *resourceName, TypeName: *typeName, ImportPath: importPath, Package: *pkg}).Apply()
+}
+
+// findRoot searches for the folder containing the go.mod of the provider
+func findRoot(folder string) (string, error) {
+	for dir := folder; ; dir = filepath.Dir(dir) {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			if !strings.Contains(string(data), "module "+modulePath+"\n") {
+				return "", fmt.Errorf("%s is not located within module `%s`", folder, modulePath)
+			}
+			return dir, nil
+		}
+		if dir == filepath.Dir(dir) {
+			return "", fmt.Errorf("%s is not located within a Go module", folder)
+		}
+	}
+}
+
+func write(file string, data []byte, overwrite bool) error {
+	if !overwrite {
+		if _, err := os.Stat(file); err == nil {
+			fmt.Printf("  skipped %s (already exists)\n", file)
+			return nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	fmt.Printf("  wrote %s\n", file)
+	return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,351 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var reservedAttributes = map[string]bool{"id": true, "count": true, "provider": true, "lifecycle": true, "depends_on": true, "for_each": true, "connection": true, "provisioner": true}
+
+// Struct is a Go struct generated either for the schema itself or for one of its types
+type Struct struct {
+	Key     string
+	Name    string
+	File    string
+	Root    bool
+	Fields  []*Field
+	Wrapper *Wrapper
+}
+
+// Wrapper is the slice type generated for types that are used as items of a `set` or `list`
+type Wrapper struct {
+	Name      string
+	Attribute string
+	Set       bool
+}
+
+type Field struct {
+	Key          string
+	Name         string
+	Attribute    string
+	GoType       string
+	TFType       string
+	Elem         string
+	Block        bool
+	Pointer      bool
+	Optional     bool
+	OmitEmpty    bool
+	Sensitive    bool
+	Reason       string
+	Description  string
+	Default      json.RawMessage
+	Precondition *Precondition
+	Property     *Property
+}
+
+type EnumType struct {
+	Key    string
+	Name   string
+	Values []*EnumValue
+}
+
+type EnumValue struct {
+	Name  string
+	Value string
+}
+
+type Model struct {
+	Schema  *Schema
+	Structs []*Struct
+	Enums   []*EnumType
+
+	structs map[string]*Struct
+	enums   map[string]*EnumType
+}
+
+func NewModel(schema *Schema) (*Model, error) {
+	model := &Model{Schema: schema, structs: map[string]*Struct{}, enums: map[string]*EnumType{}}
+	for _, key := range sortedKeys(schema.Enums) {
+		model.enum(key)
+	}
+	root := &Struct{Name: "Settings", File: "settings.go", Root: true}
+	model.Structs = append(model.Structs, root)
+	if err := model.fields(root, schema.Properties); err != nil {
+		return nil, err
+	}
+	if scopes := scopes(schema.AllowedScopes); len(scopes) > 0 {
+		field := &Field{Name: "Scope", Attribute: "scope", GoType: "*string", TFType: "schema.TypeString"}
+		if len(scopes) < len(schema.AllowedScopes) {
+			field.Optional = true
+			field.Description = fmt.Sprintf("The scope of this setting (%s). Omit this property if you want to cover the whole environment.", strings.Join(scopes, ", "))
+		} else {
+			field.Description = fmt.Sprintf("The scope of this setting (%s)", strings.Join(scopes, ", "))
+		}
+		root.Fields = append(root.Fields, field)
+	}
+	if schema.MultiObject && schema.Ordered {
+		root.Fields = append(root.Fields, &Field{
+			Name:        "InsertAfter",
+			Attribute:   "insert_after",
+			GoType:      "string",
+			TFType:      "schema.TypeString",
+			Optional:    true,
+			Description: "Because this resource allows for ordering you may specify the ID of the resource instance that comes before this instance regarding order. If not specified when creating the setting will be added to the end of the list. If not specified during update the order will remain untouched",
+		})
+	}
+	return model, nil
+}
+
+func scopes(allowedScopes []string) []string {
+	result := []string{}
+	for _, scope := range allowedScopes {
+		if scope != "environment" {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func (me *Model) typeName(name string) string {
+	for _, s := range me.Structs {
+		if s.Name == name || (s.Wrapper != nil && s.Wrapper.Name == name) {
+			return me.typeName(name + "Type")
+		}
+	}
+	for _, e := range me.enums {
+		if e.Name == name || plural(e.Name) == name {
+			return me.typeName(name + "Type")
+		}
+	}
+	if name == "Settings" {
+		return me.typeName(name + "Type")
+	}
+	return name
+}
+
+func (me *Model) enum(key string) (*EnumType, error) {
+	if enum, found := me.enums[key]; found {
+		return enum, nil
+	}
+	schemaEnum, found := me.Schema.Enums[key]
+	if !found {
+		return nil, fmt.Errorf("enum `%s` is not defined", key)
+	}
+	enum := &EnumType{Key: key, Name: me.typeName(pascal(key))}
+	names := map[string]bool{}
+	for _, item := range schemaEnum.Items {
+		value := fmt.Sprint(item.Value)
+		name := pascal(value)
+		for idx := 2; names[name]; idx++ {
+			name = pascal(value) + strconv.Itoa(idx)
+		}
+		names[name] = true
+		enum.Values = append(enum.Values, &EnumValue{Name: name, Value: value})
+	}
+	me.enums[key] = enum
+	me.Enums = append(me.Enums, enum)
+	return enum, nil
+}
+
+func (me *Model) object(key string) (*Struct, error) {
+	if s, found := me.structs[key]; found {
+		return s, nil
+	}
+	schemaType, found := me.Schema.Types[key]
+	if !found {
+		return nil, fmt.Errorf("type `%s` is not defined", key)
+	}
+	s := &Struct{Key: key, Name: me.typeName(pascal(key)), File: snake(key) + ".go"}
+	me.structs[key] = s
+	me.Structs = append(me.Structs, s)
+	if err := me.fields(s, schemaType.Properties); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (me *Model) fields(s *Struct, properties map[string]*Property) error {
+	names := map[string]bool{"Scope": s.Root, "InsertAfter": s.Root}
+	for _, key := range sortedKeys(properties) {
+		property := properties[key]
+		field := &Field{
+			Key:          key,
+			Name:         pascal(key),
+			Attribute:    snake(key),
+			Description:  description(property),
+			Default:      property.Default,
+			Precondition: property.Precondition,
+			Property:     property,
+		}
+		for names[field.Name] {
+			field.Name = field.Name + "Value"
+		}
+		names[field.Name] = true
+		if len(field.Attribute) == 0 || unicode.IsDigit(rune(field.Attribute[0])) {
+			field.Attribute = strings.TrimSuffix("value_"+field.Attribute, "_")
+		}
+		if s.Root && reservedAttributes[field.Attribute] {
+			field.Attribute = field.Attribute + "_value"
+		}
+		if err := me.field(field, property); err != nil {
+			return fmt.Errorf("property `%s`: %s", key, err.Error())
+		}
+		s.Fields = append(s.Fields, field)
+	}
+	return nil
+}
+
+func (me *Model) field(field *Field, property *Property) error {
+	reasons := []string{}
+	if property.Nullable {
+		reasons = append(reasons, "nullable")
+	}
+	if property.Precondition != nil {
+		reasons = append(reasons, "precondition")
+	}
+	optional := len(reasons) > 0
+
+	switch property.Type.Name {
+	case "set", "list":
+		if property.Items == nil {
+			return fmt.Errorf("no items specified for %s", property.Type.Name)
+		}
+		if property.MinObjects != nil && *property.MinObjects == 0 {
+			reasons = append(reasons, "minobjects == 0")
+			field.OmitEmpty = true
+			optional = true
+		}
+		field.Optional = optional
+		field.Reason = strings.Join(reasons, " & ")
+		return me.collection(field, property)
+	}
+	field.Optional = optional
+	field.Reason = strings.Join(reasons, " & ")
+
+	if typeKey, ok := property.Type.TypeName(); ok {
+		s, err := me.object(typeKey)
+		if err != nil {
+			return err
+		}
+		field.GoType = "*" + s.Name
+		field.TFType = "schema.TypeList"
+		field.Elem = fmt.Sprintf("&schema.Resource{Schema: new(%s).Schema()}", s.Name)
+		field.Block = true
+		return nil
+	}
+	if enumKey, ok := property.Type.EnumName(); ok {
+		enum, err := me.enum(enumKey)
+		if err != nil {
+			return err
+		}
+		field.GoType = enum.Name
+		field.TFType = "schema.TypeString"
+		field.Description = possibleValues(field.Description, enum)
+	} else {
+		goType, tfType := primitive(property.Type.Name)
+		field.GoType = goType
+		field.TFType = tfType
+		field.Sensitive = property.Type.Name == "secret"
+	}
+	field.Pointer = optional
+	if field.Pointer {
+		field.GoType = "*" + field.GoType
+	}
+	return nil
+}
+
+func (me *Model) collection(field *Field, property *Property) error {
+	set := property.Type.Name == "set"
+	if typeKey, ok := property.Items.Type.TypeName(); ok {
+		s, err := me.object(typeKey)
+		if err != nil {
+			return err
+		}
+		if s.Wrapper == nil {
+			s.Wrapper = &Wrapper{Name: me.typeName(plural(s.Name)), Attribute: snake(typeKey), Set: set}
+		}
+		field.GoType = s.Wrapper.Name
+		field.TFType = "schema.TypeList"
+		field.Elem = fmt.Sprintf("&schema.Resource{Schema: new(%s).Schema()}", s.Wrapper.Name)
+		field.Block = true
+		return nil
+	}
+	tfType := "schema.TypeList"
+	if set {
+		tfType = "schema.TypeSet"
+	}
+	field.TFType = tfType
+	if enumKey, ok := property.Items.Type.EnumName(); ok {
+		enum, err := me.enum(enumKey)
+		if err != nil {
+			return err
+		}
+		field.GoType = "[]" + enum.Name
+		field.Elem = "&schema.Schema{Type: schema.TypeString}"
+		field.Description = possibleValues(field.Description, enum)
+		return nil
+	}
+	goType, elemType := primitive(property.Items.Type.Name)
+	field.GoType = "[]" + goType
+	field.Elem = fmt.Sprintf("&schema.Schema{Type: %s}", elemType)
+	return nil
+}
+
+func primitive(name string) (string, string) {
+	switch name {
+	case "boolean":
+		return "bool", "schema.TypeBool"
+	case "integer":
+		return "int", "schema.TypeInt"
+	case "float":
+		return "float64", "schema.TypeFloat"
+	case "text", "secret", "setting", "time_zone", "local_time", "local_date", "local_date_time", "zoned_date_time", "uri":
+		return "string", "schema.TypeString"
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: unsupported property type `%s` - generating a string\n", name)
+	return "string", "schema.TypeString"
+}
+
+func description(property *Property) string {
+	text := property.Description
+	if len(text) == 0 {
+		text = property.DisplayName
+	}
+	if len(text) == 0 {
+		return "no documentation available"
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func possibleValues(description string, enum *EnumType) string {
+	values := []string{}
+	for _, value := range enum.Values {
+		values = append(values, "`"+value.Value+"`")
+	}
+	text := "Possible Values: " + strings.Join(values, ", ")
+	if description == "no documentation available" {
+		return text
+	}
+	return strings.TrimSuffix(description, ".") + ". " + text
+}
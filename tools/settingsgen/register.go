@@ -0,0 +1,106 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Registration describes where a generated resource needs to get hooked into the provider
+type Registration struct {
+	Root         string
+	ResourceName string
+	TypeName     string
+	ImportPath   string
+	Package      string
+}
+
+func (me *Registration) Apply() error {
+	if err := me.patch(filepath.Join("dynatrace", "export", "enums.go"), me.enums); err != nil {
+		return err
+	}
+	if err := me.patch(filepath.Join("dynatrace", "export", "resource_descriptor.go"), me.descriptor); err != nil {
+		return err
+	}
+	return me.patch(filepath.Join("provider", "provider.go"), me.provider)
+}
+
+func (me *Registration) patch(file string, fn func(string) (string, error)) error {
+	file = filepath.Join(me.Root, file)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	if strings.Contains(content, "ResourceTypes."+me.TypeName+":") || strings.Contains(content, strconv.Quote(me.ResourceName)+":") || strings.Contains(content, "\t"+me.TypeName+" ") {
+		fmt.Printf("  %s already contains `%s`\n", file, me.ResourceName)
+		return nil
+	}
+	if content, err = fn(content); err != nil {
+		return fmt.Errorf("%s: %s", file, err.Error())
+	}
+	source, err := format.Source([]byte(content))
+	if err != nil {
+		return fmt.Errorf("%s: %s", file, err.Error())
+	}
+	fmt.Printf("  patched %s\n", file)
+	return os.WriteFile(file, source, 0644)
+}
+
+// insertBefore inserts `text` right in front of the first occurrence of `anchor` after `start`
+func insertBefore(content string, start string, anchor string, text string) (string, error) {
+	idx := strings.Index(content, start)
+	if idx < 0 {
+		return "", fmt.Errorf("`%s` not found", start)
+	}
+	end := strings.Index(content[idx:], anchor)
+	if end < 0 {
+		return "", fmt.Errorf("`%s` not found", strings.TrimSpace(anchor))
+	}
+	pos := idx + end
+	return content[:pos] + text + content[pos:], nil
+}
+
+func (me *Registration) enums(content string) (string, error) {
+	content, err := insertBefore(content, "var ResourceTypes = struct {", "\n}{\n", fmt.Sprintf("\n\t%s ResourceType", me.TypeName))
+	if err != nil {
+		return "", err
+	}
+	return insertBefore(content, "var ResourceTypes = struct {", "\n}\n", fmt.Sprintf("\n\t%s,", strconv.Quote(me.ResourceName)))
+}
+
+func (me *Registration) descriptor(content string) (string, error) {
+	alias := me.Package
+	if strings.Contains(content, "\t"+alias+" \"") || strings.Contains(content, "/"+alias+"\"\n") {
+		alias = filepath.Base(filepath.Dir(me.ImportPath)) + me.Package
+	}
+	content, err := insertBefore(content, "import (", "\n)\n", fmt.Sprintf("\n\t%s %s", alias, strconv.Quote(me.ImportPath)))
+	if err != nil {
+		return "", err
+	}
+	return insertBefore(content, "var AllResources = map[ResourceType]ResourceDescriptor{", "\n}\n", fmt.Sprintf("\n\tResourceTypes.%s: NewResourceDescriptor(%s.Service),", me.TypeName, alias))
+}
+
+func (me *Registration) provider(content string) (string, error) {
+	return insertBefore(content, "ResourcesMap: map[string]*schema.Resource{", "\n\t\t},\n", fmt.Sprintf("\n\t\t\t%s: resources.NewGeneric(export.ResourceTypes.%s).Resource(),", strconv.Quote(me.ResourceName), me.TypeName))
+}
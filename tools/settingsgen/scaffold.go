@@ -0,0 +1,180 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const modulePath = "github.com/dynatrace-oss/terraform-provider-dynatrace"
+
+func ServiceFile(pkg string, importPath string, schema *Schema, args string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "//go:generate go run %s/tools/settingsgen %s\n\n", modulePath, args)
+	fmt.Fprintf(&buf, "const SchemaVersion = %s\n", strconv.Quote(schema.Version))
+	fmt.Fprintf(&buf, "const SchemaID = %s\n\n", strconv.Quote(schema.SchemaID))
+	fmt.Fprintf(&buf, "func Service(credentials *settings.Credentials) settings.CRUDService[*%s.Settings] {\n", pkg)
+	fmt.Fprintf(&buf, "\treturn settings20.Service[*%s.Settings](credentials, SchemaID, SchemaVersion)\n}\n", pkg)
+	return goFile(pkg, buf.String(),
+		pkg+" "+strconv.Quote(importPath+"/settings"),
+		strconv.Quote(modulePath+"/dynatrace/settings"),
+		strconv.Quote(modulePath+"/dynatrace/settings/services/settings20"),
+	)
+}
+
+func ServiceTestFile(pkg string, typeName string) ([]byte, error) {
+	body := fmt.Sprintf("func TestAcc%s(t *testing.T) {\n\tapi.TestAcc(t)\n}\n", typeName)
+	return goFile(pkg+"_test", body, strconv.Quote("testing"), "", strconv.Quote(modulePath+"/dynatrace/testing/api"))
+}
+
+// ExampleFile produces a minimal configuration containing all required attributes.
+// Values are taken from the defaults in the schema where available.
+func (me *Model) ExampleFile(resourceName string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "resource %s \"#name#\" {\n", strconv.Quote(resourceName))
+	me.writeExample(&buf, me.Structs[0], 1)
+	buf.WriteString("}\n")
+	return hclwrite.Format(buf.Bytes())
+}
+
+func (me *Model) writeExample(buf *bytes.Buffer, s *Struct, depth int) {
+	indent := strings.Repeat("  ", depth)
+	values := map[string]any{}
+	for _, field := range s.Fields {
+		if len(field.Key) > 0 && !field.Optional && !field.Block {
+			values[field.Key] = me.exampleValue(field)
+		}
+	}
+	for _, field := range s.Fields {
+		if s.Root && field.Key == "" {
+			if field.Name == "Scope" {
+				if field.Optional {
+					fmt.Fprintf(buf, "%sscope = \"environment\"\n", indent)
+				} else {
+					fmt.Fprintf(buf, "%sscope = \"%s\"\n", indent, scopes(me.Schema.AllowedScopes)[0]+"-1234567890000000")
+				}
+			}
+			continue
+		}
+		if field.Optional && !required(field, values) {
+			continue
+		}
+		if field.Block {
+			elem := me.elem(field)
+			fmt.Fprintf(buf, "%s%s {\n", indent, field.Attribute)
+			if elem.Wrapper != nil && elem.Wrapper.Name == field.GoType {
+				fmt.Fprintf(buf, "%s  %s {\n", indent, elem.Wrapper.Attribute)
+				me.writeExample(buf, elem, depth+2)
+				fmt.Fprintf(buf, "%s  }\n", indent)
+			} else {
+				me.writeExample(buf, elem, depth+1)
+			}
+			fmt.Fprintf(buf, "%s}\n", indent)
+			continue
+		}
+		value := me.exampleValue(field)
+		values[field.Key] = value
+		literal, _ := json.Marshal(value)
+		if strings.HasPrefix(field.GoType, "[]") {
+			literal = []byte("[ " + string(literal) + " ]")
+		}
+		fmt.Fprintf(buf, "%s%s = %s\n", indent, field.Attribute, literal)
+	}
+}
+
+// required determines whether an optional field needs to be part of the example,
+// because its precondition is met by the values chosen so far
+func required(field *Field, values map[string]any) bool {
+	if field.Precondition == nil || field.Property.Nullable || (len(field.Default) > 0 && string(field.Default) != "null") {
+		return false
+	}
+	if strings.HasPrefix(field.GoType, "[]") || (field.Block && !strings.HasPrefix(field.GoType, "*")) {
+		if field.Property.MinObjects == nil || *field.Property.MinObjects == 0 {
+			return false
+		}
+	}
+	return field.Precondition.Evaluate(values)
+}
+
+func (me *Model) elem(field *Field) *Struct {
+	for _, s := range me.Structs {
+		if "*"+s.Name == field.GoType || (s.Wrapper != nil && s.Wrapper.Name == field.GoType) {
+			return s
+		}
+	}
+	return nil
+}
+
+func (me *Model) exampleValue(field *Field) any {
+	var value any
+	if len(field.Default) > 0 && json.Unmarshal(field.Default, &value) == nil && value != nil {
+		switch v := value.(type) {
+		case string:
+			if len(v) > 0 {
+				return v
+			}
+		case bool, float64:
+			return v
+		}
+	}
+	goType := strings.TrimPrefix(strings.TrimPrefix(field.GoType, "[]"), "*")
+	switch goType {
+	case "bool":
+		return false
+	case "int", "float64":
+		return 1
+	case "string":
+		if field.Attribute == "name" || field.Property.DisplayName == "Name" {
+			return "#name#"
+		}
+		return "example"
+	}
+	for _, enum := range me.Enums {
+		if enum.Name == goType && len(enum.Values) > 0 {
+			return enum.Values[0].Value
+		}
+	}
+	return "example"
+}
+
+func DocsTemplate(resourceName string, subcategory string, importDir string, schema *Schema) []byte {
+	title := schema.DisplayName
+	if len(title) == 0 {
+		title = schema.SchemaID
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "---\nlayout: \"\"\npage_title: %s Resource - terraform-provider-dynatrace\"\nsubcategory: %s\n", resourceName, strconv.Quote(subcategory))
+	fmt.Fprintf(&buf, "description: |-\n  The resource `%s` covers configuration for %s\n---\n\n", resourceName, title)
+	fmt.Fprintf(&buf, "# %s (Resource)\n\n", resourceName)
+	buf.WriteString("-> This resource requires the API token scopes **Read settings** (`settings.read`) and **Write settings** (`settings.write`)\n\n")
+	buf.WriteString("## Dynatrace Documentation\n\n")
+	fmt.Fprintf(&buf, "- Settings API - https://www.dynatrace.com/support/help/dynatrace-api/environment-api/settings (schemaId: `%s`)\n\n", schema.SchemaID)
+	buf.WriteString("## Export Example Usage\n\n")
+	fmt.Fprintf(&buf, "- `terraform-provider-dynatrace -export %s` downloads all existing %s\n\n", resourceName, strings.ToLower(title))
+	buf.WriteString("The full documentation of the export feature is available [here](https://registry.terraform.io/providers/dynatrace-oss/dynatrace/latest/docs/guides/export-v2).\n\n")
+	buf.WriteString("## Resource Example Usage\n\n")
+	fmt.Fprintf(&buf, "{{ tffile \"%s/testdata/terraform/example_a.tf\" }}\n\n", importDir)
+	buf.WriteString("{{ .SchemaMarkdown | trimspace }}\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,184 @@
+/**
+* @license
+* Copyright 2020 Dynatrace LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dynatrace-oss/terraform-provider-dynatrace/dynatrace/settings/services/settings20/validation"
+)
+
+// Precondition is shared with the schema validation, which allows to evaluate them for example configurations
+type Precondition = validation.Precondition
+
+type Schema struct {
+	SchemaID      string               `json:"schemaId"`
+	Version       string               `json:"version"`
+	DisplayName   string               `json:"displayName"`
+	Description   string               `json:"description"`
+	AllowedScopes []string             `json:"allowedScopes"`
+	MultiObject   bool                 `json:"multiObject"`
+	Ordered       bool                 `json:"ordered"`
+	Properties    map[string]*Property `json:"properties"`
+	Types         map[string]*Type     `json:"types"`
+	Enums         map[string]*Enum     `json:"enums"`
+}
+
+type Type struct {
+	Properties map[string]*Property `json:"properties"`
+}
+
+type Enum struct {
+	Items []*EnumItem `json:"items"`
+}
+
+type EnumItem struct {
+	Value       any    `json:"value"`
+	DisplayName string `json:"displayName"`
+}
+
+type Property struct {
+	Type         TypeRef         `json:"type"`
+	DisplayName  string          `json:"displayName"`
+	Description  string          `json:"description"`
+	Nullable     bool            `json:"nullable"`
+	Default      json.RawMessage `json:"default"`
+	MinObjects   *int            `json:"minObjects"`
+	Items        *Item           `json:"items"`
+	Precondition *Precondition   `json:"precondition"`
+}
+
+type Item struct {
+	Type TypeRef `json:"type"`
+}
+
+type TypeRef struct {
+	Name string
+	Ref  string
+}
+
+func (me *TypeRef) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &me.Name); err == nil {
+		return nil
+	}
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	me.Ref = ref.Ref
+	return nil
+}
+
+func (me TypeRef) TypeName() (string, bool) {
+	return strings.CutPrefix(me.Ref, "#/types/")
+}
+
+func (me TypeRef) EnumName() (string, bool) {
+	return strings.CutPrefix(me.Ref, "#/enums/")
+}
+
+func LoadSchema(file string) (*Schema, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// words splits property, type and enum names into their words.
+// camelCase, PascalCase, snake_case, kebab-case and dotted names are supported
+func words(s string) []string {
+	result := []string{}
+	current := []rune{}
+	runes := []rune(s)
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, string(current))
+			current = []rune{}
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return result
+}
+
+// pascal produces an exported Go identifier, e.g. `config-item-title` becomes `ConfigItemTitle`
+func pascal(s string) string {
+	var sb strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	result := sb.String()
+	if len(result) == 0 || unicode.IsDigit([]rune(result)[0]) {
+		result = "Value" + result
+	}
+	return result
+}
+
+// snake produces a Terraform attribute name, e.g. `configItemTitle` becomes `config_item_title`
+func snake(s string) string {
+	parts := []string{}
+	for _, word := range words(s) {
+		parts = append(parts, strings.ToLower(word))
+	}
+	return strings.Join(parts, "_")
+}
+
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsAny(s[len(s)-2:len(s)-1], "aeiou"):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}